@@ -0,0 +1,211 @@
+package recoil
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/jhdrn/go-recoil/response"
+)
+
+// AccessLogOption is a functional option for configuring an access log.
+type AccessLogOption func(*accessLog)
+
+// WithSampler configures the access log to only log requests for which sample
+// returns true, see SampleRate.
+func WithSampler(sample func(r *http.Request, status int) bool) AccessLogOption {
+	return func(l *accessLog) {
+		l.sample = sample
+	}
+}
+
+// WithHeaders configures the access log to log the request and response
+// headers. The values of the redacted headers are replaced.
+func WithHeaders() AccessLogOption {
+	return func(l *accessLog) {
+		l.headers = true
+	}
+}
+
+// WithRedactedHeaders adds headers whose values are not logged to the default
+// Authorization, Cookie, Proxy-Authorization and Set-Cookie headers.
+func WithRedactedHeaders(names ...string) AccessLogOption {
+	return func(l *accessLog) {
+		for _, name := range names {
+			l.redacted[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+}
+
+// SampleRate returns a sampler for WithSampler keeping the given fraction of
+// requests. Requests resulting in a server error are always kept.
+func SampleRate(rate float64) func(r *http.Request, status int) bool {
+	return func(r *http.Request, status int) bool {
+		return status >= http.StatusInternalServerError || rand.Float64() < rate
+	}
+}
+
+// redactedValue replaces the values of redacted headers.
+const redactedValue = "[REDACTED]"
+
+// AccessLog returns middleware logging an entry for each request to logger.
+// The entry has the method, path, route pattern, status, number of bytes
+// written, latency and, if the content of the response is an error, the error.
+//
+// The status, route pattern and error are taken from the Response when the
+// request is served by a Handler or a Router, otherwise the status written to
+// the response writer is logged. Server errors are logged at the error level,
+// other requests at the info level:
+//
+//	http.ListenAndServe(":8080", recoil.AccessLog(slog.Default())(router))
+func AccessLog(logger *slog.Logger, options ...AccessLogOption) func(http.Handler) http.Handler {
+	l := &accessLog{
+		logger: logger,
+		redacted: map[string]bool{
+			"Authorization":       true,
+			"Cookie":              true,
+			"Proxy-Authorization": true,
+			"Set-Cookie":          true,
+		},
+	}
+
+	for _, opt := range options {
+		opt(l)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			l.serve(next, w, r)
+		})
+	}
+}
+
+// accessLog is the configuration of the AccessLog middleware.
+type accessLog struct {
+	logger   *slog.Logger
+	sample   func(r *http.Request, status int) bool
+	headers  bool
+	redacted map[string]bool
+}
+
+// serve serves the request using next and logs its entry.
+func (l *accessLog) serve(next http.Handler, w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	record := &accessRecord{}
+	cw := &countingWriter{ResponseWriter: w}
+	next.ServeHTTP(cw, r.WithContext(context.WithValue(r.Context(), accessRecordKey{}, record)))
+
+	status := record.status
+	if status == 0 {
+		status = cw.status
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	if l.sample != nil && !l.sample(r, status) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.String("route", record.pattern),
+		slog.Int("status", status),
+		slog.Int64("bytes", cw.bytes),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if record.err != nil {
+		attrs = append(attrs, slog.String("error", record.err.Error()))
+	}
+	if l.headers {
+		attrs = append(attrs,
+			slog.Any("request_headers", l.redact(r.Header)),
+			slog.Any("response_headers", l.redact(w.Header())),
+		)
+	}
+
+	level := slog.LevelInfo
+	if status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	l.logger.LogAttrs(r.Context(), level, "request", attrs...)
+}
+
+// redact returns a copy of header with the values of redacted headers
+// replaced.
+func (l *accessLog) redact(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for key, values := range header {
+		if l.redacted[http.CanonicalHeaderKey(key)] {
+			redacted[key] = []string{redactedValue}
+			continue
+		}
+		redacted[key] = append([]string(nil), values...)
+	}
+	return redacted
+}
+
+type accessRecordKey struct{}
+
+// accessRecord holds the details of a response recorded by ServeHTTP for the
+// access log.
+type accessRecord struct {
+	pattern string
+	status  int
+	err     error
+}
+
+// recordAccess records the details of res for the access log, if r is served
+// by AccessLog.
+func recordAccess(r *http.Request, res Response, status int) {
+	record, ok := r.Context().Value(accessRecordKey{}).(*accessRecord)
+	if !ok {
+		return
+	}
+
+	record.pattern = RoutePattern(r)
+	record.status = status
+	if data, ok := findResponse[interface{ Data() response.ResponseData }](res); ok {
+		record.err, _ = data.Data().Content.(error)
+	}
+}
+
+// countingWriter is a response writer counting the bytes written and
+// recording the status.
+type countingWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *countingWriter) WriteHeader(status int) {
+	if w.status == 0 && status >= http.StatusOK {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Flush flushes the underlying response writer, if it supports flushing.
+func (w *countingWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying response writer, for http.ResponseController.
+func (w *countingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,136 @@
+package recoil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAccessLog(t *testing.T) {
+
+	router := NewRouter()
+	router.Get("/users/{id}", func(r *http.Request) Response {
+		return response.OK().WithContent(map[string]string{"id": Param(r, "id")})
+	})
+	router.Get("/fail", func(r *http.Request) Response {
+		return response.InternalServerError().WithContent(errors.New("database unavailable"))
+	})
+
+	var buf bytes.Buffer
+	h := AccessLog(slog.New(slog.NewJSONHandler(&buf, nil)))(router)
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.org/users/42", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.org/fail", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.org/missing", nil))
+
+	entries := decodeEntries(t, &buf)
+	assert.Len(t, entries, 3)
+
+	assert.Equal(t, "INFO", entries[0]["level"])
+	assert.Equal(t, "request", entries[0]["msg"])
+	assert.Equal(t, "GET", entries[0]["method"])
+	assert.Equal(t, "/users/42", entries[0]["path"])
+	assert.Equal(t, "/users/{id}", entries[0]["route"])
+	assert.Equal(t, float64(http.StatusOK), entries[0]["status"])
+	assert.Equal(t, float64(rw.Body.Len()), entries[0]["bytes"])
+	assert.Contains(t, entries[0], "latency")
+	assert.NotContains(t, entries[0], "error")
+
+	assert.Equal(t, "ERROR", entries[1]["level"])
+	assert.Equal(t, "/fail", entries[1]["route"])
+	assert.Equal(t, float64(http.StatusInternalServerError), entries[1]["status"])
+	assert.Equal(t, "database unavailable", entries[1]["error"])
+
+	assert.Equal(t, "", entries[2]["route"])
+	assert.Equal(t, float64(http.StatusNotFound), entries[2]["status"])
+}
+
+func TestAccessLogHTTPHandler(t *testing.T) {
+
+	var buf bytes.Buffer
+	h := AccessLog(slog.New(slog.NewJSONHandler(&buf, nil)))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("short and stout"))
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.org/teapot", nil))
+
+	entries := decodeEntries(t, &buf)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, float64(http.StatusTeapot), entries[0]["status"])
+	assert.Equal(t, float64(len("short and stout")), entries[0]["bytes"])
+}
+
+func TestAccessLogHeaders(t *testing.T) {
+
+	h := Handler(func(r *http.Request) Response {
+		return response.OK().WithHeaderEntry("X-Session", "secret")
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	r := httptest.NewRequest(http.MethodGet, "http://example.org", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set("Accept", "application/json")
+
+	AccessLog(logger)(h).ServeHTTP(httptest.NewRecorder(), r)
+	AccessLog(logger, WithHeaders(), WithRedactedHeaders("x-session"))(h).ServeHTTP(httptest.NewRecorder(), r)
+
+	entries := decodeEntries(t, &buf)
+	assert.Len(t, entries, 2)
+	assert.NotContains(t, entries[0], "request_headers")
+	assert.Equal(t, map[string]any{
+		"Authorization": []any{"[REDACTED]"},
+		"Accept":        []any{"application/json"},
+	}, entries[1]["request_headers"])
+	assert.Equal(t, []any{"[REDACTED]"}, entries[1]["response_headers"].(map[string]any)["X-Session"])
+	assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+}
+
+func TestAccessLogSampler(t *testing.T) {
+
+	status := http.StatusOK
+	h := Handler(func(r *http.Request) Response {
+		return response.NewBuilder().WithStatus(status)
+	})
+
+	var buf bytes.Buffer
+	logged := AccessLog(slog.New(slog.NewJSONHandler(&buf, nil)), WithSampler(SampleRate(0)))(h)
+
+	logged.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.org", nil))
+	assert.Empty(t, buf.String())
+
+	status = http.StatusBadGateway
+	logged.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.org", nil))
+	entries := decodeEntries(t, &buf)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, float64(http.StatusBadGateway), entries[0]["status"])
+
+	buf.Reset()
+	status = http.StatusOK
+	logged = AccessLog(slog.New(slog.NewJSONHandler(&buf, nil)), WithSampler(SampleRate(1)))(h)
+	logged.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.org", nil))
+	assert.Len(t, decodeEntries(t, &buf), 1)
+}
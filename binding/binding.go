@@ -0,0 +1,314 @@
+// Package binding fills structs from the path, query, header and cookie values
+// of a request.
+package binding
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jhdrn/go-recoil"
+	"github.com/jhdrn/go-recoil/response"
+)
+
+// ErrRequired is the error of a FieldError for a required value which is
+// missing.
+var ErrRequired = errors.New("value is required")
+
+// FieldError describes a request value that could not be bound to a field.
+type FieldError struct {
+	// Field is the name of the struct field.
+	Field string
+	// Source is the source of the value: "path", "query", "header" or
+	// "cookie".
+	Source string
+	// Name is the name of the value in its source.
+	Name string
+	// Err is the reason the value could not be bound.
+	Err error
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s %q: %v", e.Source, sourceNoun(e.Source), e.Name, e.Err)
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is the aggregated error returned by Bind. It lists every value that
+// could not be bound, and can be used as content of a 400 Bad Request response:
+//
+//	if err := binding.Bind(r, &params); err != nil {
+//		return response.BadRequest().WithContent(err)
+//	}
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// JSONAPIErrors returns the errors as JSON:API error objects, making Errors
+// usable with response.JSONAPIFormatter.
+func (e Errors) JSONAPIErrors() []response.JSONAPIError {
+	apiErrors := make([]response.JSONAPIError, len(e))
+	for i, err := range e {
+		apiErrors[i] = response.JSONAPIError{
+			Status: strconv.Itoa(http.StatusBadRequest),
+			Title:  http.StatusText(http.StatusBadRequest),
+			Detail: err.Error(),
+		}
+		switch err.Source {
+		case "header":
+			apiErrors[i].Source = map[string]string{"header": err.Name}
+		default:
+			apiErrors[i].Source = map[string]string{"parameter": err.Name}
+		}
+	}
+	return apiErrors
+}
+
+// Bind fills the struct pointed to by dst with values from r. Fields are bound
+// using struct tags naming the source and name of the value:
+//
+//	type Params struct {
+//		ID      int       `path:"id"`
+//		Limit   int       `query:"limit" default:"20"`
+//		Tags    []string  `query:"tag"`
+//		Since   time.Time `query:"since" layout:"2006-01-02"`
+//		TraceID string    `header:"X-Trace-Id,required"`
+//		Session string    `cookie:"session"`
+//	}
+//
+// Path values are read using recoil.Param. The "required" option makes a
+// missing or empty value an error, while the default tag provides a value to
+// use instead. Supported field types are strings, booleans, numbers,
+// time.Duration, time.Time (RFC 3339 unless a layout tag is given), types
+// implementing encoding.TextUnmarshaler, and pointers and slices of those.
+// Slices are bound from all values of a query parameter or header. Embedded
+// structs are bound recursively.
+//
+// If any values cannot be bound, Bind returns an Errors listing all of them.
+// Will panic if dst is not a pointer to a struct or a tagged field has an
+// unsupported type, whether or not r has a value for it.
+func Bind(r *http.Request, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("binding: destination must be a non-nil pointer to a struct, got %T", dst))
+	}
+
+	var errs Errors
+	bindStruct(r, v.Elem(), &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+var sources = []string{"path", "query", "header", "cookie"}
+
+// bindStruct binds the fields of the struct v.
+func bindStruct(r *http.Request, v reflect.Value, errs *Errors) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			bindStruct(r, v.Field(i), errs)
+			continue
+		}
+
+		for _, source := range sources {
+			tag, ok := sf.Tag.Lookup(source)
+			if !ok {
+				continue
+			}
+			if !sf.IsExported() {
+				panic(fmt.Errorf("binding: field %s.%s is not exported", t, sf.Name))
+			}
+			if !supported(sf.Type) {
+				panic(fmt.Errorf("binding: field %s.%s has unsupported type %s", t, sf.Name, sf.Type))
+			}
+
+			name, options, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = sf.Name
+			}
+
+			values := lookup(r, source, name)
+			if len(values) == 0 {
+				if def, ok := sf.Tag.Lookup("default"); ok {
+					values = []string{def}
+				} else if options == "required" {
+					*errs = append(*errs, FieldError{Field: sf.Name, Source: source, Name: name, Err: ErrRequired})
+					break
+				} else {
+					break
+				}
+			}
+
+			if err := setValue(v.Field(i), values, sf.Tag.Get("layout")); err != nil {
+				*errs = append(*errs, FieldError{Field: sf.Name, Source: source, Name: name, Err: err})
+			}
+			break
+		}
+	}
+}
+
+// lookup returns the non-empty values of name in the given source of r.
+func lookup(r *http.Request, source string, name string) []string {
+	var values []string
+	switch source {
+	case "path":
+		values = []string{recoil.Param(r, name)}
+	case "query":
+		values = r.URL.Query()[name]
+	case "header":
+		values = r.Header.Values(name)
+	case "cookie":
+		for _, cookie := range r.Cookies() {
+			if cookie.Name == name {
+				values = append(values, cookie.Value)
+			}
+		}
+	}
+
+	var nonEmpty []string
+	for _, value := range values {
+		if value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	return nonEmpty
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// supported reports whether fields of type t can be bound, so that unsupported
+// types panic whether or not the request has a value for them.
+func supported(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice && !reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == durationType || t == timeType || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// setValue sets the field v from the given values.
+func setValue(v reflect.Value, values []string, layout string) error {
+	if v.Kind() == reflect.Slice && !v.Addr().Type().Implements(textUnmarshalerType) {
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setSingle(slice.Index(i), value, layout); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return setSingle(v, values[0], layout)
+}
+
+// setSingle parses the value into v.
+func setSingle(v reflect.Value, value string, layout string) error {
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setSingle(ptr.Elem(), value, layout); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	switch v.Type() {
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", value)
+		}
+		v.SetInt(int64(d))
+		return nil
+	case timeType:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return fmt.Errorf("invalid time %q, expected format %q", value, layout)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", value)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		v.SetFloat(f)
+	default:
+		panic(fmt.Errorf("binding: unsupported field type %s", v.Type()))
+	}
+	return nil
+}
+
+// sourceNoun returns the noun used for values of the given source in error
+// messages.
+func sourceNoun(source string) string {
+	if source == "path" || source == "query" {
+		return "parameter"
+	}
+	return "value"
+}
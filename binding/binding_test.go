@@ -0,0 +1,132 @@
+package binding
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jhdrn/go-recoil"
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type Paging struct {
+	Limit  int  `query:"limit" default:"20"`
+	Offset uint `query:"offset"`
+}
+
+type params struct {
+	Paging
+	ID       int64         `path:"id"`
+	Tags     []string      `query:"tag"`
+	Since    time.Time     `query:"since" layout:"2006-01-02"`
+	Until    *time.Time    `query:"until"`
+	Timeout  time.Duration `query:"timeout"`
+	Verbose  bool          `query:"verbose"`
+	Ratio    float64       `query:"ratio"`
+	IP       net.IP        `query:"ip"`
+	TraceID  string        `header:"X-Trace-Id,required"`
+	Accept   []string      `header:"Accept"`
+	Session  string        `cookie:"session"`
+	Ignored  string
+	Optional *int `query:"optional"`
+}
+
+func TestBind(t *testing.T) {
+
+	r := httptest.NewRequest(http.MethodGet, "/users/42?tag=a&tag=b&offset=5&since=2023-05-01&until=2023-05-02T10:00:00Z&timeout=1.5s&verbose=true&ratio=0.25&ip=127.0.0.1", nil)
+	r.Header.Set("X-Trace-Id", "trace")
+	r.Header.Add("Accept", "text/html")
+	r.Header.Add("Accept", "application/json")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "secret"})
+	r = recoil.WithParams(r, map[string]string{"id": "42"})
+
+	var p params
+	assert.NoError(t, Bind(r, &p))
+
+	until := time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, params{
+		Paging:  Paging{Limit: 20, Offset: 5},
+		ID:      42,
+		Tags:    []string{"a", "b"},
+		Since:   time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+		Until:   &until,
+		Timeout: 1500 * time.Millisecond,
+		Verbose: true,
+		Ratio:   0.25,
+		IP:      net.ParseIP("127.0.0.1"),
+		TraceID: "trace",
+		Accept:  []string{"text/html", "application/json"},
+		Session: "secret",
+	}, p)
+}
+
+func TestBindErrors(t *testing.T) {
+
+	r := httptest.NewRequest(http.MethodGet, "/users/abc?limit=x&since=yesterday&verbose=maybe&ip=nope", nil)
+	r = recoil.WithParams(r, map[string]string{"id": "abc"})
+
+	var p params
+	err := Bind(r, &p)
+
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 6)
+
+	assert.Equal(t, FieldError{Field: "Limit", Source: "query", Name: "limit", Err: errs[0].Err}, errs[0])
+	assert.Equal(t, `query parameter "limit": invalid integer "x"`, errs[0].Error())
+	assert.Equal(t, "ID", errs[1].Field)
+	assert.Equal(t, "Since", errs[2].Field)
+	assert.Equal(t, "Verbose", errs[3].Field)
+	assert.Equal(t, "IP", errs[4].Field)
+	assert.ErrorIs(t, errs[5], ErrRequired)
+	assert.Equal(t, `header value "X-Trace-Id": value is required`, errs[5].Error())
+
+	body, _ := io.ReadAll(response.BadRequest().WithContent(err).Body())
+	assert.Contains(t, string(body), `query parameter \"limit\": invalid integer \"x\"; path parameter \"id\"`)
+}
+
+func TestErrorsJSONAPIErrors(t *testing.T) {
+
+	errs := Errors{
+		{Field: "Limit", Source: "query", Name: "limit", Err: errors.New("invalid")},
+		{Field: "TraceID", Source: "header", Name: "X-Trace-Id", Err: ErrRequired},
+	}
+
+	apiErrors := errs.JSONAPIErrors()
+
+	assert.Equal(t, map[string]string{"parameter": "limit"}, apiErrors[0].Source)
+	assert.Equal(t, map[string]string{"header": "X-Trace-Id"}, apiErrors[1].Source)
+	assert.Equal(t, "400", apiErrors[1].Status)
+}
+
+type unsupportedParams struct {
+	Y []chan int `query:"y"`
+}
+
+func TestBindPanicsOnBadDestination(t *testing.T) {
+
+	r := httptest.NewRequest(http.MethodGet, "/?x=1", nil)
+
+	assert.Panics(t, func() {
+		var p params
+		_ = Bind(r, p)
+	})
+
+	assert.Panics(t, func() {
+		var p struct {
+			X map[string]string `query:"x"`
+		}
+		_ = Bind(r, &p)
+	})
+
+	assert.PanicsWithError(t, "binding: field binding.unsupportedParams.Y has unsupported type []chan int", func() {
+		var p unsupportedParams
+		_ = Bind(r, &p)
+	})
+}
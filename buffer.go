@@ -0,0 +1,89 @@
+package recoil
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// Buffer returns middleware buffering response bodies of unknown size up to
+// limit bytes, so that ServeHTTP can set the Content-Length header instead of
+// using chunked encoding. Larger bodies are streamed after the buffered part,
+// and bodies reporting their size are not buffered:
+//
+//	router.Use(recoil.Buffer(64 << 10))
+func Buffer(limit int64) Middleware {
+	return func(next Handler) Handler {
+		return func(r *http.Request) Response {
+			return bufferedResponse{
+				Response: bindRequest(next(r), r),
+				limit:    limit,
+			}
+		}
+	}
+}
+
+// bufferedResponse is a response buffering its body up to limit bytes.
+type bufferedResponse struct {
+	Response
+	limit int64
+}
+
+// Body returns the body of the response. If it is smaller than the limit it
+// is returned as a *bytes.Reader, otherwise the buffered part is followed by
+// the rest of the body.
+func (res bufferedResponse) Body() io.Reader {
+	body := res.Response.Body()
+	if _, ok := bodyLength(body); ok {
+		return body
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(body, res.limit+1))
+	if err != nil {
+		return &bufferedBody{Reader: io.MultiReader(bytes.NewReader(buf), errReader{err}), body: body}
+	}
+
+	if int64(len(buf)) <= res.limit {
+		if closer, ok := body.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				return errReader{err}
+			}
+		}
+		return bytes.NewReader(buf)
+	}
+
+	return &bufferedBody{Reader: io.MultiReader(bytes.NewReader(buf), body), body: body}
+}
+
+// ContentLength returns the content length reported by the response, if any.
+func (res bufferedResponse) ContentLength() (int64, bool) {
+	return contentLength(res.Response)
+}
+
+// Unwrap returns the buffered response.
+func (res bufferedResponse) Unwrap() Response {
+	return res.Response
+}
+
+// bufferedBody is a body of which the start has been buffered. Closing it
+// closes the original body.
+type bufferedBody struct {
+	io.Reader
+	body io.Reader
+}
+
+func (b *bufferedBody) Close() error {
+	if closer, ok := b.body.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// errReader is a reader failing with err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
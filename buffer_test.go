@@ -0,0 +1,82 @@
+package recoil
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// unsizedReader hides the size of the underlying reader.
+type unsizedReader struct {
+	io.Reader
+}
+
+func streamHandler(body *closingReader) Handler {
+	return func(r *http.Request) Response {
+		return response.NewBuilder(response.WithConfig(response.Config{
+			Formatter: response.PlainTextFormatter{},
+		})).WithStream(body)
+	}
+}
+
+func TestBuffer(t *testing.T) {
+
+	body := &closingReader{Reader: unsizedReader{strings.NewReader("small body")}}
+
+	rw := httptest.NewRecorder()
+	Buffer(16)(streamHandler(body)).ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, "10", rw.Header().Get("Content-Length"))
+	assert.Equal(t, "small body", rw.Body.String())
+	assert.True(t, body.closed)
+}
+
+func TestBufferLargeBody(t *testing.T) {
+
+	body := &closingReader{Reader: unsizedReader{strings.NewReader("a body larger than the limit")}}
+
+	rw := httptest.NewRecorder()
+	Buffer(16)(streamHandler(body)).ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Empty(t, rw.Header().Get("Content-Length"))
+	assert.Equal(t, "a body larger than the limit", rw.Body.String())
+	assert.True(t, body.closed)
+}
+
+func TestBufferUnbuffered(t *testing.T) {
+
+	rw := httptest.NewRecorder()
+	streamHandler(&closingReader{Reader: unsizedReader{strings.NewReader("small body")}}).
+		ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Empty(t, rw.Header().Get("Content-Length"))
+	assert.Equal(t, "small body", rw.Body.String())
+}
+
+func TestBufferBindsRequest(t *testing.T) {
+
+	router := NewRouter(WithResponseConfig(response.Config{
+		Formatter: response.NegotiatingFormatter{
+			Offers: []response.MediaTypeFormatter{
+				{MediaType: "application/json", Formatter: response.JSONFormatter{}},
+				{MediaType: "application/xml", Formatter: response.XMLFormatter{}},
+			},
+		},
+	}))
+	router.Use(Buffer(1024))
+
+	r := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	r.Header.Set("Accept", "application/xml")
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, r)
+
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+	assert.Equal(t, "application/xml", rw.Header().Get("Content-Type"))
+	assert.NotEmpty(t, rw.Header().Get("Content-Length"))
+}
@@ -2,9 +2,13 @@
 package recoil
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+
+	"github.com/jhdrn/go-recoil/response"
 )
 
 // Response is an interface that defines the methods used to format a response
@@ -21,17 +25,84 @@ type Handler func(r *http.Request) Response
 // ServeHTTP calls Handler(r) and writes the Response to w. Will panic if
 // writing the response fails.
 //
+// If the response is a response.Builder which has not been bound to a request,
+// it will be bound to r before it is formatted.
+//
+// For HEAD requests the body is not generated. If the response can tell the
+// length of its body without generating it, by implementing
+// ContentLength() (int64, bool) like response.Builder does, the Content-Length
+// header is set. Instead of the body, the response is closed if it implements
+// io.Closer, like response.Builder does to close streamed content.
+//
+// Unless set by the response, the Content-Length header is set when the body
+// reports its size by implementing Len() int, like *bytes.Reader does, or
+// Size() int64. Other bodies are written using chunked encoding, see Buffer
+// for buffering them instead.
+//
+// If the response implements EarlyHints() []string, like response.Builder
+// does, a 103 Early Hints response with the returned Link header values is sent
+// before the final response. If the response implements
+// Trailers() map[string]func() string, the trailers are declared before and
+// set after the body is written. These optional interfaces are also found on
+// responses wrapped by middleware implementing Unwrap() Response.
+//
 // If the response body implements the io.Closer interface, it will be closed
 // after it has been written to the response writer.
+//
+// When served by AccessLog, the status, route pattern and error content of the
+// response are recorded for the access log.
 func (f Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	response := f(r)
+	res := bindRequest(f(r), r)
 
-	for k, v := range response.Header() {
+	if hinter, ok := findResponse[earlyHinter](res); ok {
+		if links := hinter.EarlyHints(); len(links) > 0 {
+			for _, link := range links {
+				w.Header().Add("Link", link)
+			}
+			w.WriteHeader(http.StatusEarlyHints)
+		}
+	}
+
+	for k, v := range res.Header() {
 		w.Header()[k] = v
 	}
-	w.WriteHeader(response.Status())
 
-	body := response.Body()
+	if r.Method == http.MethodHead {
+		if length, ok := contentLength(res); ok {
+			w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+		}
+		status := res.Status()
+		recordAccess(r, res, status)
+		w.WriteHeader(status)
+
+		if closer, ok := findResponse[io.Closer](res); ok {
+			if err := closer.Close(); err != nil {
+				panic(fmt.Errorf("failed to close response: %w", err))
+			}
+		}
+		return
+	}
+
+	status := res.Status()
+	recordAccess(r, res, status)
+	body := res.Body()
+
+	var trailers map[string]func() string
+	if trailer, ok := findResponse[trailerer](res); ok {
+		trailers = trailer.Trailers()
+	}
+	for key := range trailers {
+		w.Header().Add("Trailer", key)
+	}
+
+	if len(trailers) > 0 {
+		w.Header().Del("Content-Length")
+	} else if w.Header().Get("Content-Length") == "" && response.BodyAllowed(status) {
+		if length, ok := bodyLength(body); ok {
+			w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+		}
+	}
+	w.WriteHeader(status)
 
 	_, err := io.Copy(w, body)
 	if err != nil {
@@ -44,6 +115,27 @@ func (f Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			panic(fmt.Errorf("failed to close body: %w", err))
 		}
 	}
+
+	for key, value := range trailers {
+		w.Header().Set(key, value())
+	}
+}
+
+// ContextHandler is a handler taking the context of the request as its first
+// argument, making the context explicit for handlers passing it on.
+type ContextHandler func(ctx context.Context, r *http.Request) Response
+
+// Handler returns a Handler calling h with the context of the request.
+func (h ContextHandler) Handler() Handler {
+	return func(r *http.Request) Response {
+		return h(r.Context(), r)
+	}
+}
+
+// ServeHTTP calls h with the context of the request and writes the Response
+// to w, like Handler.ServeHTTP.
+func (h ContextHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.Handler().ServeHTTP(w, r)
 }
 
 // HandlerFunc creates a standard library compatible handler function
@@ -52,3 +144,57 @@ func HandlerFunc(h Handler) func(http.ResponseWriter, *http.Request) {
 		h.ServeHTTP(w, r)
 	}
 }
+
+// bindRequest binds r to res if res is a response.Builder without a request.
+func bindRequest(res Response, r *http.Request) Response {
+	if b, ok := res.(response.Builder); ok && b.Request() == nil {
+		return b.WithRequest(r)
+	}
+	return res
+}
+
+type earlyHinter interface {
+	EarlyHints() []string
+}
+
+type trailerer interface {
+	Trailers() map[string]func() string
+}
+
+// findResponse returns the first response implementing T, looking through
+// responses wrapped by middleware implementing Unwrap() Response.
+func findResponse[T any](res Response) (T, bool) {
+	for res != nil {
+		if t, ok := res.(T); ok {
+			return t, true
+		}
+		wrapper, ok := res.(interface{ Unwrap() Response })
+		if !ok {
+			break
+		}
+		res = wrapper.Unwrap()
+	}
+
+	var zero T
+	return zero, false
+}
+
+// contentLength returns the length of the body of res if it can be determined
+// without generating the body.
+func contentLength(res Response) (int64, bool) {
+	if l, ok := res.(interface{ ContentLength() (int64, bool) }); ok {
+		return l.ContentLength()
+	}
+	return 0, false
+}
+
+// bodyLength returns the size of the body if it reports it.
+func bodyLength(body io.Reader) (int64, bool) {
+	switch b := body.(type) {
+	case interface{ Len() int }:
+		return int64(b.Len()), true
+	case interface{ Size() int64 }:
+		return b.Size(), true
+	}
+	return 0, false
+}
@@ -2,28 +2,33 @@ package recoil
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
 	"testing"
+	"time"
 
+	"github.com/jhdrn/go-recoil/response"
 	"github.com/stretchr/testify/assert"
 )
 
-type response struct {
+type testResponse struct {
 	body   io.Reader
 	header http.Header
 	status int
 }
 
-func (r response) Body() io.Reader {
+func (r testResponse) Body() io.Reader {
 	return r.body
 }
-func (r response) Header() http.Header {
+func (r testResponse) Header() http.Header {
 	return r.header
 }
-func (r response) Status() int {
+func (r testResponse) Status() int {
 	return r.status
 }
 
@@ -31,7 +36,7 @@ func TestHandler(t *testing.T) {
 
 	body := []byte("body")
 
-	responseObj := response{
+	responseObj := testResponse{
 		body: bytes.NewReader(body),
 		header: http.Header{
 			"Content-Type": []string{"text/plain"},
@@ -50,7 +55,8 @@ func TestHandler(t *testing.T) {
 	respBody, _ := io.ReadAll(rw.Body)
 
 	assert.Equal(t, body, respBody)
-	assert.Equal(t, responseObj.header, rw.Result().Header)
+	assert.Equal(t, responseObj.header.Get("Content-Type"), rw.Result().Header.Get("Content-Type"))
+	assert.Equal(t, "4", rw.Result().Header.Get("Content-Length"))
 	assert.Equal(t, responseObj.status, rw.Code)
 }
 
@@ -73,7 +79,7 @@ func TestCloserBody(t *testing.T) {
 
 	closer := &closer{bytes.NewReader(body), false}
 
-	responseObj := response{
+	responseObj := testResponse{
 		body: closer,
 		header: http.Header{
 			"Content-Type": []string{"text/plain"},
@@ -100,7 +106,7 @@ func TestCloserBody(t *testing.T) {
 func TestHandlerFunc(t *testing.T) {
 
 	body := []byte("body")
-	responseObj := response{
+	responseObj := testResponse{
 		body: bytes.NewReader(body),
 		header: http.Header{
 			"Content-Type": []string{"text/plain"},
@@ -120,6 +126,158 @@ func TestHandlerFunc(t *testing.T) {
 	respBody, _ := io.ReadAll(rw.Body)
 
 	assert.Equal(t, body, respBody)
-	assert.Equal(t, responseObj.header, rw.Result().Header)
+	assert.Equal(t, responseObj.header.Get("Content-Type"), rw.Result().Header.Get("Content-Type"))
+	assert.Equal(t, "4", rw.Result().Header.Get("Content-Length"))
 	assert.Equal(t, responseObj.status, rw.Code)
 }
+
+func TestHandlerBindsRequest(t *testing.T) {
+
+	h := Handler(func(r *http.Request) Response {
+		return response.NewBuilder(response.WithConfig(response.Config{
+			Formatter: response.NegotiatingFormatter{
+				Offers: []response.MediaTypeFormatter{
+					{MediaType: "application/json", Formatter: response.JSONFormatter{}},
+					{MediaType: "application/xml", Formatter: response.XMLFormatter{}},
+				},
+			},
+		}))
+	})
+
+	rw := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://example.org", nil)
+	r.Header.Set("Accept", "application/xml")
+	h.ServeHTTP(rw, r)
+
+	assert.Equal(t, "application/xml", rw.Header().Get("Content-Type"))
+}
+
+type panickingFormatter struct {
+	response.JSONFormatter
+}
+
+func (f panickingFormatter) FormatBody(responseData response.ResponseData) io.Reader {
+	panic("body should not be formatted")
+}
+
+func TestHandlerHead(t *testing.T) {
+
+	builder := response.NewBuilder(response.WithConfig(response.Config{
+		Formatter: panickingFormatter{},
+	}))
+
+	h := Handler(func(r *http.Request) Response {
+		return builder.WithContent(map[string]string{"key": "value"})
+	})
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodHead, "http://example.org", nil))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assert.Empty(t, rw.Header().Get("Content-Length"))
+	assert.Empty(t, rw.Body.String())
+
+	h = Handler(func(r *http.Request) Response {
+		return builder.WithStream(bytes.NewReader([]byte("body")))
+	})
+
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodHead, "http://example.org", nil))
+
+	assert.Equal(t, "4", rw.Header().Get("Content-Length"))
+	assert.Empty(t, rw.Body.String())
+
+	stream := &closer{Reader: bytes.NewReader([]byte("body"))}
+	h = Handler(func(r *http.Request) Response {
+		return builder.WithStream(stream)
+	})
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodHead, "http://example.org", nil))
+
+	assert.True(t, stream.closed)
+}
+
+func TestHandlerTrailers(t *testing.T) {
+
+	h := Handler(func(r *http.Request) Response {
+		body := &closer{Reader: bytes.NewReader([]byte("streamed"))}
+		return response.NewBuilder().
+			WithStream(body).
+			WithTrailer("x-checksum", func() string {
+				if !body.closed {
+					return "not closed"
+				}
+				return "abc"
+			}).
+			WithTrailer("X-Status", func() string { return "complete" })
+	})
+
+	server := httptest.NewServer(Buffer(1024)(h))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err, "failed to read body reader")
+
+	assert.Equal(t, "streamed", string(body))
+	assert.Equal(t, int64(-1), res.ContentLength)
+	assert.Equal(t, http.Header{"X-Checksum": {"abc"}, "X-Status": {"complete"}}, res.Trailer)
+}
+
+func TestHandlerEarlyHints(t *testing.T) {
+
+	h := Handler(func(r *http.Request) Response {
+		return response.OK().
+			WithEarlyHints("</style.css>; rel=preload; as=style").
+			WithEarlyHints("</app.js>; rel=preload; as=script")
+	})
+
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	var hints []http.Header
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			assert.Equal(t, http.StatusEarlyHints, code)
+			hints = append(hints, http.Header(header))
+			return nil
+		},
+	}
+
+	r, _ := http.NewRequestWithContext(httptrace.WithClientTrace(context.Background(), trace), http.MethodGet, server.URL, nil)
+	res, err := http.DefaultClient.Do(r)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Len(t, hints, 1)
+	assert.Equal(t, []string{"</style.css>; rel=preload; as=style", "</app.js>; rel=preload; as=script"}, hints[0].Values("Link"))
+}
+
+type contextKey struct{}
+
+func TestContextHandler(t *testing.T) {
+
+	h := ContextHandler(func(ctx context.Context, r *http.Request) Response {
+		return response.OK().WithContent(ctx.Value(contextKey{}))
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "http://example.org", nil)
+	r = r.WithContext(context.WithValue(r.Context(), contextKey{}, "value"))
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `"value"`, rw.Body.String())
+
+	rw = httptest.NewRecorder()
+	Timeout(time.Second)(h.Handler()).ServeHTTP(rw, r)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `"value"`, rw.Body.String())
+}
@@ -0,0 +1,90 @@
+package recoil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Materialized is a formatted response held in memory. Unlike most responses,
+// its body can be read any number of times. It unwraps to the materialized
+// response, so trailers, early hints and the response data of a
+// response.Builder are kept.
+type Materialized struct {
+	source Response
+	header http.Header
+	status int
+	body   []byte
+}
+
+// Materialize formats res once, reading its body into memory, and returns a
+// response replaying the formatted status, header and body. If the body
+// implements the io.Closer interface, it is closed after it has been read, even
+// if reading it fails.
+//
+// A response.Builder is formatted as is, so it should be bound to the request
+// using WithRequest first if its formatter depends on the request.
+func Materialize(res Response) (*Materialized, error) {
+	m := &Materialized{
+		source: res,
+		header: res.Header(),
+		status: res.Status(),
+	}
+
+	body := res.Body()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	m.body = data
+
+	if closer, ok := body.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close body: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// Body returns a reader of the formatted body.
+func (m *Materialized) Body() io.Reader {
+	return bytes.NewReader(m.body)
+}
+
+// Bytes returns the formatted body.
+func (m *Materialized) Bytes() []byte {
+	return m.body
+}
+
+// ContentLength returns the length of the formatted body.
+func (m *Materialized) ContentLength() (int64, bool) {
+	return int64(len(m.body)), true
+}
+
+// Close does nothing, as the body has been read and closed by Materialize. It
+// keeps the materialized response from being closed again when serving HEAD
+// requests.
+func (m *Materialized) Close() error {
+	return nil
+}
+
+// Unwrap returns the materialized response.
+func (m *Materialized) Unwrap() Response {
+	return m.source
+}
+
+// Header returns the formatted header map.
+func (m *Materialized) Header() http.Header {
+	return m.header
+}
+
+// Status returns the formatted status code.
+func (m *Materialized) Status() int {
+	return m.status
+}
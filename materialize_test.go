@@ -0,0 +1,97 @@
+package recoil
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type closingReader struct {
+	io.Reader
+	closed bool
+	err    error
+}
+
+func (r *closingReader) Close() error {
+	r.closed = true
+	return r.err
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func TestMaterialize(t *testing.T) {
+
+	body := &closingReader{Reader: strings.NewReader("streamed")}
+	res := response.Created().WithStream(body).WithHeaderEntry("X-Foo", "bar")
+
+	m, err := Materialize(res)
+	assert.NoError(t, err)
+	assert.True(t, body.closed)
+
+	for i := 0; i < 2; i++ {
+		b, err := io.ReadAll(m.Body())
+		assert.NoError(t, err, "failed to read body reader")
+		assert.Equal(t, "streamed", string(b))
+	}
+
+	assert.Equal(t, []byte("streamed"), m.Bytes())
+	assert.Equal(t, http.StatusCreated, m.Status())
+	assert.Equal(t, "bar", m.Header().Get("X-Foo"))
+
+	rw := httptest.NewRecorder()
+	Handler(func(r *http.Request) Response {
+		return m
+	}).ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusCreated, rw.Code)
+	assert.Equal(t, "streamed", rw.Body.String())
+}
+
+func TestMaterializeErrors(t *testing.T) {
+
+	failing := &closingReader{Reader: failingReader{}}
+	_, err := Materialize(response.OK().WithStream(failing))
+	assert.EqualError(t, err, "failed to read body: read failed")
+	assert.True(t, failing.closed)
+
+	body := &closingReader{Reader: strings.NewReader(""), err: errors.New("close failed")}
+	_, err = Materialize(response.OK().WithStream(body))
+	assert.EqualError(t, err, "failed to close body: close failed")
+}
+
+func TestMaterializeUnwrap(t *testing.T) {
+
+	body := &closingReader{Reader: strings.NewReader("streamed")}
+	res := response.OK().WithStream(body).WithTrailer("X-Checksum", func() string { return "sum" })
+
+	m, err := Materialize(res)
+	assert.NoError(t, err)
+	assert.Equal(t, Response(res), m.Unwrap())
+
+	rw := httptest.NewRecorder()
+	Handler(func(r *http.Request) Response {
+		return m
+	}).ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, "streamed", rw.Body.String())
+	assert.Equal(t, "sum", rw.Result().Trailer.Get("X-Checksum"))
+
+	body.closed = false
+	rw = httptest.NewRecorder()
+	Handler(func(r *http.Request) Response {
+		return m
+	}).ServeHTTP(rw, httptest.NewRequest(http.MethodHead, "/", nil))
+
+	assert.Equal(t, "8", rw.Header().Get("Content-Length"))
+	assert.False(t, body.closed)
+}
@@ -0,0 +1,393 @@
+// Package openapi generates OpenAPI 3.1 documents from the routes registered
+// on a recoil.Router.
+package openapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jhdrn/go-recoil"
+	"github.com/jhdrn/go-recoil/response"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.1.0"
+
+// Operation describes the operation of a route. Types are given as values of
+// the type, e.g. User{} or []User{}, and are converted to schemas using
+// reflection on their encoding/json representation.
+type Operation struct {
+	// OperationID is the unique identifier of the operation.
+	OperationID string
+	// Summary is a short summary of the operation.
+	Summary string
+	// Description is a longer description of the operation.
+	Description string
+	// Tags groups the operation.
+	Tags []string
+	// Deprecated marks the operation as deprecated.
+	Deprecated bool
+	// Hidden excludes the route from generated documents.
+	Hidden bool
+	// Parameters is a struct with fields tagged for the binding package, which
+	// are documented as the path, query, header and cookie parameters of the
+	// operation.
+	Parameters any
+	// RequestBody is the type of the request body.
+	RequestBody any
+	// Responses lists the responses of the operation. If empty, a single 200
+	// OK response without content is documented.
+	Responses []Response
+	// MediaType is the media type of the request and response bodies. By
+	// default "application/json" is used.
+	MediaType string
+}
+
+// Response describes a response of an operation.
+type Response struct {
+	// Status is the status code of the response.
+	Status int
+	// Description describes the response. By default the status text is used.
+	Description string
+	// Content is the type of the response body, or nil for no body.
+	Content any
+}
+
+type operationKey struct{}
+
+// Describe annotates the route with the operation and returns the route:
+//
+//	openapi.Describe(router.Get("/users/{id}", getUser), openapi.Operation{
+//		Summary:    "Get a user",
+//		Parameters: UserParams{},
+//		Responses: []openapi.Response{
+//			{Status: http.StatusOK, Content: User{}},
+//			{Status: http.StatusNotFound},
+//		},
+//	})
+func Describe(route *recoil.Route, operation Operation) *recoil.Route {
+	return route.WithMetadata(operationKey{}, operation)
+}
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string              `json:"openapi" yaml:"openapi"`
+	Info       Info                `json:"info" yaml:"info"`
+	Paths      map[string]PathItem `json:"paths" yaml:"paths"`
+	Components *Components         `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+// Info provides metadata about the API.
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// PathItem maps the lower case methods of a path to their operations.
+type PathItem map[string]*OperationObject
+
+// OperationObject is an OpenAPI operation object.
+type OperationObject struct {
+	OperationID string                     `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Summary     string                     `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []*Parameter               `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody               `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*ResponseObject `json:"responses" yaml:"responses"`
+	Deprecated  bool                       `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+// Parameter is an OpenAPI parameter object.
+type Parameter struct {
+	Name     string  `json:"name" yaml:"name"`
+	In       string  `json:"in" yaml:"in"`
+	Required bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *Schema `json:"schema" yaml:"schema"`
+}
+
+// RequestBody is an OpenAPI request body object.
+type RequestBody struct {
+	Content  map[string]*MediaType `json:"content" yaml:"content"`
+	Required bool                  `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// ResponseObject is an OpenAPI response object.
+type ResponseObject struct {
+	Description string                `json:"description" yaml:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// Header is an OpenAPI header object.
+type Header struct {
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// MediaType is an OpenAPI media type object.
+type MediaType struct {
+	Schema *Schema `json:"schema" yaml:"schema"`
+}
+
+// Components holds the schemas referenced from the document.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+}
+
+// Load reads an OpenAPI document in JSON or YAML format from r.
+func Load(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var document Document
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	return &document, nil
+}
+
+// Generate returns the OpenAPI document for the routes. Routes without an
+// Operation are documented with a single 200 OK response, routes with a hidden
+// Operation are left out.
+func Generate(info Info, routes []*recoil.Route) *Document {
+	g := &schemaGenerator{schemas: map[string]*Schema{}}
+
+	document := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]PathItem{},
+	}
+
+	for _, route := range routes {
+		operation, _ := route.Metadata(operationKey{}).(Operation)
+		if operation.Hidden {
+			continue
+		}
+
+		path, pathParams := openAPIPath(route.Pattern)
+		item, ok := document.Paths[path]
+		if !ok {
+			item = PathItem{}
+			document.Paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = g.operation(operation, pathParams)
+	}
+
+	if len(g.schemas) > 0 {
+		document.Components = &Components{Schemas: g.schemas}
+	}
+
+	return document
+}
+
+// Handler returns a handler serving the OpenAPI document of the routes
+// registered on router. The document is generated on the first request, and
+// served as JSON or YAML depending on the Accept header.
+func Handler(router *recoil.Router, info Info) recoil.Handler {
+	var once sync.Once
+	var document *Document
+
+	config := response.Config{
+		Formatter: response.NegotiatingFormatter{
+			Offers: []response.MediaTypeFormatter{
+				{MediaType: "application/json", Formatter: response.JSONFormatter{}},
+				{MediaType: "application/yaml", Formatter: response.YAMLFormatter{}},
+			},
+		},
+	}
+
+	return func(r *http.Request) recoil.Response {
+		once.Do(func() {
+			document = Generate(info, router.Routes())
+		})
+		return response.NewBuilder(response.WithConfig(config)).WithContent(document)
+	}
+}
+
+// operation returns the operation object for the operation.
+func (g *schemaGenerator) operation(operation Operation, pathParams []string) *OperationObject {
+	mediaType := operation.MediaType
+	if mediaType == "" {
+		mediaType = "application/json"
+	}
+
+	object := &OperationObject{
+		OperationID: operation.OperationID,
+		Summary:     operation.Summary,
+		Description: operation.Description,
+		Tags:        operation.Tags,
+		Parameters:  g.parameters(operation.Parameters, pathParams),
+		Responses:   map[string]*ResponseObject{},
+		Deprecated:  operation.Deprecated,
+	}
+
+	if operation.RequestBody != nil {
+		object.RequestBody = &RequestBody{
+			Content: map[string]*MediaType{
+				mediaType: {Schema: g.schema(reflect.TypeOf(operation.RequestBody))},
+			},
+			Required: true,
+		}
+	}
+
+	responses := operation.Responses
+	if len(responses) == 0 {
+		responses = []Response{{Status: http.StatusOK}}
+	}
+	for _, res := range responses {
+		description := res.Description
+		if description == "" {
+			description = http.StatusText(res.Status)
+		}
+
+		responseObject := &ResponseObject{Description: description}
+		if res.Content != nil {
+			responseObject.Content = map[string]*MediaType{
+				mediaType: {Schema: g.schema(reflect.TypeOf(res.Content))},
+			}
+		}
+		object.Responses[strconv.Itoa(res.Status)] = responseObject
+	}
+
+	return object
+}
+
+var parameterSources = []string{"path", "query", "header", "cookie"}
+
+// parameters returns the parameters documented by the binding tags of the
+// fields of params. Path parameters of the route which have no field are
+// documented as strings.
+func (g *schemaGenerator) parameters(params any, pathParams []string) []*Parameter {
+	var parameters []*Parameter
+	if params != nil {
+		t := reflect.TypeOf(params)
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			parameters = g.structParameters(t, parameters)
+		}
+	}
+
+	for _, name := range pathParams {
+		found := false
+		for _, p := range parameters {
+			if p.In == "path" && p.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			parameters = append(parameters, &Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+
+	sort.SliceStable(parameters, func(i, j int) bool {
+		return parameters[i].In == "path" && parameters[j].In != "path"
+	})
+
+	return parameters
+}
+
+// structParameters appends the parameters of the fields of the struct type t.
+func (g *schemaGenerator) structParameters(t reflect.Type, parameters []*Parameter) []*Parameter {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			parameters = g.structParameters(sf.Type, parameters)
+			continue
+		}
+
+		for _, source := range parameterSources {
+			tag, ok := sf.Tag.Lookup(source)
+			if !ok {
+				continue
+			}
+
+			name, options, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = sf.Name
+			}
+
+			schema := g.schema(sf.Type)
+			if layout := sf.Tag.Get("layout"); layout != "" && schema.Format == "date-time" {
+				schema = &Schema{Type: "string"}
+			}
+			if def, ok := sf.Tag.Lookup("default"); ok {
+				schema.Default = parseDefault(schema, def)
+			}
+
+			parameters = append(parameters, &Parameter{
+				Name:     name,
+				In:       source,
+				Required: source == "path" || options == "required",
+				Schema:   schema,
+			})
+			break
+		}
+	}
+	return parameters
+}
+
+// parseDefault parses the default tag value def as a value of the schema's
+// type. Array defaults are a single item, as bound by the binding package.
+// Values that cannot be parsed are returned as is.
+func parseDefault(schema *Schema, def string) any {
+	var value any
+	var err error
+	switch schema.Type {
+	case "integer":
+		value, err = strconv.ParseInt(def, 10, 64)
+	case "number":
+		value, err = strconv.ParseFloat(def, 64)
+	case "boolean":
+		value, err = strconv.ParseBool(def)
+	case "array":
+		if schema.Items != nil {
+			return []any{parseDefault(schema.Items, def)}
+		}
+		return []any{def}
+	default:
+		return def
+	}
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// openAPIPath converts a route pattern to an OpenAPI path, returning the names
+// of its path parameters. A trailing wildcard is documented as the "*" path
+// parameter.
+func openAPIPath(pattern string) (string, []string) {
+	var params []string
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "*":
+			segments[i] = "{*}"
+			params = append(params, "*")
+		case strings.HasPrefix(segment, "{"):
+			params = append(params, segment[1:len(segment)-1])
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
@@ -0,0 +1,228 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jhdrn/go-recoil"
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type User struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserParams struct {
+	ID      int64  `path:"id"`
+	Expand  bool   `query:"expand" default:"false"`
+	TraceID string `header:"X-Trace-Id,required"`
+}
+
+func ok(r *http.Request) recoil.Response {
+	return response.OK()
+}
+
+func newRouter() *recoil.Router {
+	router := recoil.NewRouter()
+
+	Describe(router.Get("/users/{id}", ok), Operation{
+		OperationID: "getUser",
+		Summary:     "Get a user",
+		Tags:        []string{"users"},
+		Parameters:  UserParams{},
+		Responses: []Response{
+			{Status: http.StatusOK, Content: User{}},
+			{Status: http.StatusNotFound, Description: "No such user"},
+		},
+	})
+	Describe(router.Post("/users", ok), Operation{
+		RequestBody: User{},
+		Responses:   []Response{{Status: http.StatusCreated, Content: User{}}},
+		MediaType:   "application/yaml",
+	})
+	router.Get("/files/*", ok)
+	Describe(router.Get("/internal", ok), Operation{Hidden: true})
+
+	return router
+}
+
+func TestGenerate(t *testing.T) {
+
+	document := Generate(Info{Title: "Users", Version: "1.0"}, newRouter().Routes())
+
+	assert.Equal(t, Version, document.OpenAPI)
+	assert.Equal(t, Info{Title: "Users", Version: "1.0"}, document.Info)
+	assert.Len(t, document.Paths, 3)
+
+	assert.Equal(t, &OperationObject{
+		OperationID: "getUser",
+		Summary:     "Get a user",
+		Tags:        []string{"users"},
+		Parameters: []*Parameter{
+			{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "integer", Format: "int64"}},
+			{Name: "expand", In: "query", Schema: &Schema{Type: "boolean", Default: false}},
+			{Name: "X-Trace-Id", In: "header", Required: true, Schema: &Schema{Type: "string"}},
+		},
+		Responses: map[string]*ResponseObject{
+			"200": {
+				Description: "OK",
+				Content: map[string]*MediaType{
+					"application/json": {Schema: &Schema{Ref: "#/components/schemas/User"}},
+				},
+			},
+			"404": {Description: "No such user"},
+		},
+	}, document.Paths["/users/{id}"]["get"])
+
+	post := document.Paths["/users"]["post"]
+	assert.Equal(t, &RequestBody{
+		Content: map[string]*MediaType{
+			"application/yaml": {Schema: &Schema{Ref: "#/components/schemas/User"}},
+		},
+		Required: true,
+	}, post.RequestBody)
+	assert.Contains(t, post.Responses["201"].Content, "application/yaml")
+
+	assert.Equal(t, &OperationObject{
+		Parameters: []*Parameter{
+			{Name: "*", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+		},
+		Responses: map[string]*ResponseObject{
+			"200": {Description: "OK"},
+		},
+	}, document.Paths["/files/{*}"]["get"])
+
+	assert.Contains(t, document.Components.Schemas, "User")
+}
+
+func TestParseDefault(t *testing.T) {
+
+	tests := []struct {
+		schema   *Schema
+		def      string
+		expected any
+	}{
+		{&Schema{Type: "integer"}, "20", int64(20)},
+		{&Schema{Type: "number"}, "0.5", 0.5},
+		{&Schema{Type: "boolean"}, "true", true},
+		{&Schema{Type: "string"}, "20", "20"},
+		{&Schema{Type: "array", Items: &Schema{Type: "integer"}}, "1", []any{int64(1)}},
+		{&Schema{Type: "integer"}, "many", "many"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, parseDefault(test.schema, test.def))
+	}
+}
+
+func TestHandler(t *testing.T) {
+
+	router := newRouter()
+	router.Get("/openapi", Handler(router, Info{Title: "Users", Version: "1.0"}))
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/openapi", nil))
+
+	var document map[string]any
+	assert.NoError(t, json.Unmarshal(rw.Body.Bytes(), &document))
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assert.Equal(t, "3.1.0", document["openapi"])
+	assert.Contains(t, document["paths"], "/openapi")
+
+	r := httptest.NewRequest(http.MethodGet, "/openapi", nil)
+	r.Header.Set("Accept", "application/yaml")
+	rw = httptest.NewRecorder()
+	router.ServeHTTP(rw, r)
+
+	document = nil
+	assert.NoError(t, yaml.Unmarshal(rw.Body.Bytes(), &document))
+	assert.Equal(t, "application/yaml", rw.Header().Get("Content-Type"))
+	assert.Equal(t, "3.1.0", document["openapi"])
+	assert.Contains(t, rw.Body.String(), "#/components/schemas/User")
+}
+
+func TestLoad(t *testing.T) {
+
+	document, err := Load(strings.NewReader(`
+openapi: 3.1.0
+info:
+  title: Users
+  version: "1.0"
+paths:
+  /users/{id}:
+    get:
+      responses:
+        "200":
+          description: OK
+          headers:
+            ETag:
+              required: true
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+        role:
+          type: string
+          enum: [admin, user]
+        nickname:
+          type: [string, "null"]
+`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Users", document.Info.Title)
+	assert.True(t, document.Paths["/users/{id}"]["get"].Responses["200"].Headers["ETag"].Required)
+	assert.Equal(t, []any{"admin", "user"}, document.Components.Schemas["User"].Properties["role"].Enum)
+	assert.Equal(t, &Schema{Type: "string", Nullable: true}, document.Components.Schemas["User"].Properties["nickname"])
+
+	jsonDocument, err := Load(strings.NewReader(`{"openapi":"3.1.0","info":{"title":"Users","version":"1.0"},"paths":{}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "3.1.0", jsonDocument.OpenAPI)
+
+	_, err = Load(strings.NewReader(`paths: [`))
+	assert.Error(t, err)
+
+	_, err = Load(strings.NewReader(`{"components":{"schemas":{"ID":{"type":["string","integer"]}}}}`))
+	assert.ErrorContains(t, err, "only one type besides \"null\" is supported")
+}
+
+func TestSchemaEncoding(t *testing.T) {
+
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"tags": {Type: "array", Nullable: true, Items: &Schema{Type: "string"}},
+			"none": {Type: "null"},
+			"user": {Ref: "#/components/schemas/User", Nullable: true},
+		},
+	}
+
+	data, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","properties":{"tags":{"type":["array","null"],"items":{"type":"string"}},"none":{"type":"null"},"user":{"anyOf":[{"$ref":"#/components/schemas/User"},{"type":"null"}]}}}`, string(data))
+
+	var decoded Schema
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, schema, &decoded)
+
+	data, err = yaml.Marshal(schema)
+	assert.NoError(t, err)
+
+	decoded = Schema{}
+	assert.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.Equal(t, schema, &decoded)
+	assert.Contains(t, string(data), "- array\n")
+}
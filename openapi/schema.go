@@ -0,0 +1,310 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is an OpenAPI schema object.
+//
+// Of the types OpenAPI 3.1 allows to be listed, a single type optionally
+// combined with "null" is supported, e.g. type: [string, "null"], which is
+// represented by Type and Nullable. Documents listing other combinations of
+// types cannot be loaded. A nullable reference is encoded as
+// anyOf: [{$ref: ...}, {type: "null"}].
+type Schema struct {
+	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	// Type is the type of the value, or "" for any type.
+	Type string `json:"-" yaml:"-"`
+	// Nullable reports whether the value may also be null.
+	Nullable             bool               `json:"-" yaml:"-"`
+	Format               string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Enum                 []any              `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Default              any                `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// schemaFields has the fields of Schema without its methods.
+type schemaFields Schema
+
+// schemaObject is the encoded form of a Schema, with its type as a string or a
+// list of strings.
+type schemaObject struct {
+	schemaFields `yaml:",inline"`
+	Type         any       `json:"type,omitempty" yaml:"type,omitempty"`
+	AnyOf        []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+}
+
+// object returns the encoded form of the schema.
+func (s Schema) object() schemaObject {
+	object := schemaObject{schemaFields: schemaFields(s)}
+	if s.Ref != "" && s.Nullable {
+		object.Ref = ""
+		object.AnyOf = []*Schema{{Ref: s.Ref}, {Type: "null"}}
+		return object
+	}
+	if s.Type != "" {
+		object.Type = s.Type
+		if s.Nullable && s.Type != "null" {
+			object.Type = []string{s.Type, "null"}
+		}
+	}
+	return object
+}
+
+// decode sets the schema from its encoded form.
+func (s *Schema) decode(object schemaObject) error {
+	*s = Schema(object.schemaFields)
+	if len(object.AnyOf) == 2 && object.AnyOf[0].Ref != "" && object.AnyOf[1].Type == "null" {
+		s.Ref = object.AnyOf[0].Ref
+		s.Nullable = true
+	}
+	return s.setType(object.Type)
+}
+
+// setType sets the type of the schema from its encoded form.
+func (s *Schema) setType(value any) error {
+	var types []any
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case string:
+		s.Type = value
+		return nil
+	case []any:
+		types = value
+	default:
+		return fmt.Errorf("invalid schema type %v", value)
+	}
+
+	for _, t := range types {
+		name, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("invalid schema type %v", t)
+		}
+		if name == "null" {
+			s.Nullable = true
+			continue
+		}
+		if s.Type != "" {
+			return fmt.Errorf("unsupported schema type %v: only one type besides \"null\" is supported", value)
+		}
+		s.Type = name
+	}
+	if s.Type == "" && s.Nullable {
+		s.Type = "null"
+	}
+	return nil
+}
+
+func (s Schema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.object())
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var object schemaObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	return s.decode(object)
+}
+
+func (s Schema) MarshalYAML() (any, error) {
+	return s.object(), nil
+}
+
+func (s *Schema) UnmarshalYAML(node *yaml.Node) error {
+	var object schemaObject
+	if err := node.Decode(&object); err != nil {
+		return err
+	}
+	return s.decode(object)
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaGenerator converts types to schemas, collecting the schemas of named
+// struct types as components.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	// names maps the types of the components to their names.
+	names map[reflect.Type]string
+}
+
+// schema returns a new schema for the type t. Named struct types are added to
+// the components and referenced. Slices and maps are nullable, as nil slices
+// and maps are encoded as null.
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// Nil slices are encoded as null, arrays never are.
+		nullable := t.Kind() == reflect.Slice
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Nullable: nullable, Format: "byte"}
+		}
+		return &Schema{Type: "array", Nullable: nullable, Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", Nullable: true, AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		name, ok := g.names[t]
+		if !ok {
+			// Reserve the name before generating the object to support
+			// recursive types.
+			name = g.componentName(t)
+			g.schemas[name] = nil
+			g.schemas[name] = g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	return &Schema{}
+}
+
+// componentName returns a new component name for the named type t. The name of
+// the type is used unless another type has it already, in which case it is
+// qualified with the package path. Characters not allowed in component names,
+// e.g. in the type arguments of generic types, are replaced by underscores.
+func (g *schemaGenerator) componentName(t reflect.Type) string {
+	if g.names == nil {
+		g.names = map[reflect.Type]string{}
+	}
+
+	name := sanitizeName(t.Name())
+	if _, taken := g.schemas[name]; taken {
+		name = sanitizeName(t.PkgPath() + "." + t.Name())
+	}
+	for i, base := 2, name; ; i++ {
+		if _, taken := g.schemas[name]; !taken {
+			break
+		}
+		name = base + "_" + strconv.Itoa(i)
+	}
+
+	g.names[t] = name
+	return name
+}
+
+// sanitizeName replaces the characters not allowed in component names by
+// underscores, trimming them from the end.
+func sanitizeName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		}
+		return '_'
+	}, name)
+	return strings.TrimRight(sanitized, "_")
+}
+
+// object returns the object schema of the struct type t, following the rules
+// of encoding/json for field names and embedded structs. Fields are required
+// unless they are pointers, which are nullable, or tagged omitempty.
+func (g *schemaGenerator) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.fields(t, schema)
+	return schema
+}
+
+// fields adds the fields of the struct type t to the object schema.
+func (g *schemaGenerator) fields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.fields(ft, schema)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		var property *Schema
+		if hasOption(options, "string") {
+			property = &Schema{Type: "string"}
+		} else {
+			property = g.schema(sf.Type)
+		}
+		schema.Properties[name] = property
+
+		if hasOption(options, "omitempty") {
+			continue
+		}
+		if sf.Type.Kind() == reflect.Pointer {
+			// Nil pointers are encoded as null.
+			property.Nullable = true
+			continue
+		}
+		schema.Required = append(schema.Required, name)
+	}
+}
+
+// hasOption reports whether the comma separated tag options contain option.
+func hasOption(options string, option string) bool {
+	for options != "" {
+		var o string
+		o, options, _ = strings.Cut(options, ",")
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
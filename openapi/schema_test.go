@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Base struct {
+	ID      int64     `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type Node struct {
+	Base
+	Name     string            `json:"name"`
+	Parent   *Node             `json:"parent"`
+	Children []Node            `json:"children,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Count    uint8             `json:"count,string"`
+	Score    float64           `json:"score"`
+	IP       net.IP            `json:"ip"`
+	Data     []byte            `json:"data"`
+	Raw      json.RawMessage   `json:"raw"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+func TestSchema(t *testing.T) {
+
+	g := &schemaGenerator{schemas: map[string]*Schema{}}
+
+	assert.Equal(t, &Schema{Type: "array", Nullable: true, Items: &Schema{Ref: "#/components/schemas/Node"}}, g.schema(reflect.TypeOf([]*Node{})))
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}}, g.schema(reflect.TypeOf([2]string{})))
+
+	assert.Equal(t, &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"id":       {Type: "integer", Format: "int64"},
+			"created":  {Type: "string", Format: "date-time"},
+			"name":     {Type: "string"},
+			"parent":   {Ref: "#/components/schemas/Node", Nullable: true},
+			"children": {Type: "array", Nullable: true, Items: &Schema{Ref: "#/components/schemas/Node"}},
+			"labels":   {Type: "object", Nullable: true, AdditionalProperties: &Schema{Type: "string"}},
+			"count":    {Type: "string"},
+			"score":    {Type: "number", Format: "double"},
+			"ip":       {Type: "string"},
+			"data":     {Type: "string", Nullable: true, Format: "byte"},
+			"raw":      {},
+		},
+		Required: []string{"id", "created", "name", "count", "score", "ip", "data", "raw"},
+	}, g.schemas["Node"])
+
+	assert.Len(t, g.schemas, 1)
+}
+
+func TestSchemaAnonymousStruct(t *testing.T) {
+
+	g := &schemaGenerator{schemas: map[string]*Schema{}}
+
+	schema := g.schema(reflect.TypeOf(struct {
+		Enabled bool  `json:"enabled"`
+		Limit   int32 `json:"limit,omitempty"`
+		Other   any
+	}{}))
+
+	assert.Equal(t, &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"enabled": {Type: "boolean"},
+			"limit":   {Type: "integer", Format: "int32"},
+			"Other":   {},
+		},
+		Required: []string{"enabled", "Other"},
+	}, schema)
+	assert.Empty(t, g.schemas)
+}
+
+// packageNode refers to Node where it is shadowed by a local type.
+type packageNode = Node
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+}
+
+func TestSchemaComponentNames(t *testing.T) {
+
+	type Node struct {
+		Value string `json:"value"`
+	}
+
+	g := &schemaGenerator{schemas: map[string]*Schema{}}
+
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Base"}, g.schema(reflect.TypeOf(Base{})))
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Node"}, g.schema(reflect.TypeOf(Node{})))
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/github.com_jhdrn_go-recoil_openapi.Node"}, g.schema(reflect.TypeOf(packageNode{})))
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Page_github.com_jhdrn_go-recoil_openapi.Base"}, g.schema(reflect.TypeOf(Page[Base]{})))
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Node"}, g.schema(reflect.TypeOf(Node{})))
+
+	assert.Equal(t, &Schema{Type: "object", Properties: map[string]*Schema{"value": {Type: "string"}}, Required: []string{"value"}}, g.schemas["Node"])
+	assert.Len(t, g.schemas, 4)
+}
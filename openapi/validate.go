@@ -0,0 +1,335 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jhdrn/go-recoil"
+	"github.com/jhdrn/go-recoil/response"
+)
+
+// ValidationError describes how a response differs from its OpenAPI
+// operation.
+type ValidationError struct {
+	// Method is the method of the request.
+	Method string
+	// Pattern is the route pattern of the request.
+	Pattern string
+	// Status is the status of the response.
+	Status int
+	// Problems lists the mismatches found.
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("response %d to %s %s does not match the OpenAPI document: %s",
+		e.Status, e.Method, e.Pattern, strings.Join(e.Problems, "; "))
+}
+
+// TestingT is the subset of testing.TB used by TestReporter.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// TestReporter returns a report function for Validate which fails the test.
+func TestReporter(t TestingT) func(*http.Request, error) {
+	return func(r *http.Request, err error) {
+		t.Helper()
+		t.Errorf("%v", err)
+	}
+}
+
+// Validate returns middleware validating the status, headers and body of the
+// responses of routed requests against the operations in document. Mismatches
+// are passed to report as a *ValidationError.
+//
+// The response is materialized using recoil.Materialize to be validated, so the
+// middleware is meant to be used in tests and during development:
+//
+//	router.Use(openapi.Validate(document, openapi.TestReporter(t)))
+//
+// Bodies of JSON media types are validated against their schema. Null values
+// are only accepted for nullable schemas.
+func Validate(document *Document, report func(*http.Request, error)) recoil.Middleware {
+	return func(next recoil.Handler) recoil.Handler {
+		return func(r *http.Request) recoil.Response {
+			res := next(r)
+			if b, ok := res.(response.Builder); ok && b.Request() == nil {
+				res = b.WithRequest(r)
+			}
+
+			materialized, err := recoil.Materialize(res)
+			if err != nil {
+				panic(fmt.Errorf("failed to materialize response: %w", err))
+			}
+
+			if problems := document.validate(r, materialized, statusBody(res)); len(problems) > 0 {
+				report(r, &ValidationError{
+					Method:   r.Method,
+					Pattern:  recoil.RoutePattern(r),
+					Status:   materialized.Status(),
+					Problems: problems,
+				})
+			}
+
+			return materialized
+		}
+	}
+}
+
+// validate returns the problems found validating res against the operation
+// of the route of r. HEAD requests are validated against the GET operation
+// unless a HEAD operation is documented, without validating the body. If
+// defaultBody is set, the body is the one formatters write for nil content,
+// which is accepted for responses documented without content.
+func (d *Document) validate(r *http.Request, res *recoil.Materialized, defaultBody bool) []string {
+	pattern := recoil.RoutePattern(r)
+	if pattern == "" {
+		return nil
+	}
+
+	path, _ := openAPIPath(pattern)
+	operation := d.Paths[path][strings.ToLower(r.Method)]
+	head := r.Method == http.MethodHead
+	if operation == nil && head {
+		// The router serves HEAD requests using GET routes.
+		operation = d.Paths[path]["get"]
+	}
+	if operation == nil {
+		return []string{fmt.Sprintf("no operation documented for %s %s", r.Method, path)}
+	}
+
+	responseObject := operation.response(res.Status())
+	if responseObject == nil {
+		return []string{fmt.Sprintf("status %d is not documented", res.Status())}
+	}
+
+	var problems []string
+
+	names := make([]string, 0, len(responseObject.Headers))
+	for name := range responseObject.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if responseObject.Headers[name].Required && res.Header().Get(name) == "" {
+			problems = append(problems, fmt.Sprintf("required header %q is missing", name))
+		}
+	}
+
+	if head {
+		return problems
+	}
+
+	if len(responseObject.Content) == 0 {
+		if len(res.Bytes()) > 0 && !defaultBody {
+			problems = append(problems, "body is not documented")
+		}
+		return problems
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(res.Header().Get("Content-Type"))
+	content, ok := responseObject.Content[mediaType]
+	if !ok {
+		return append(problems, fmt.Sprintf("content type %q is not documented", mediaType))
+	}
+
+	if content.Schema == nil || !isJSON(mediaType) {
+		return problems
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(res.Bytes()))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return append(problems, fmt.Sprintf("body is not valid JSON: %v", err))
+	}
+
+	return append(problems, d.validateValue(content.Schema, value, "$")...)
+}
+
+// statusBody reports whether res has nil content, which formatters format as
+// a default body describing the status, like {"message": "Not Found"}.
+// Responses wrapped by middleware implementing Unwrap() recoil.Response are
+// unwrapped.
+func statusBody(res recoil.Response) bool {
+	for res != nil {
+		if data, ok := res.(interface{ Data() response.ResponseData }); ok {
+			return data.Data().Content == nil
+		}
+		wrapper, ok := res.(interface{ Unwrap() recoil.Response })
+		if !ok {
+			break
+		}
+		res = wrapper.Unwrap()
+	}
+	return false
+}
+
+// response returns the response object documented for the status, falling
+// back to a range like "4XX" and then "default".
+func (o *OperationObject) response(status int) *ResponseObject {
+	code := strconv.Itoa(status)
+	if res, ok := o.Responses[code]; ok {
+		return res
+	}
+	if res, ok := o.Responses[code[:1]+"XX"]; ok {
+		return res
+	}
+	return o.Responses["default"]
+}
+
+// isJSON reports whether the media type is JSON or a JSON based format.
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// validateValue returns the problems found validating a decoded JSON value
+// against the schema. The path locates the value in the body.
+func (d *Document) validateValue(schema *Schema, value any, path string) []string {
+	if value == nil && schema.Nullable {
+		return nil
+	}
+
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		if d.Components == nil || d.Components.Schemas[name] == nil {
+			return []string{fmt.Sprintf("%s: unresolved reference %q", path, schema.Ref)}
+		}
+		schema = d.Components.Schemas[name]
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		return []string{fmt.Sprintf("%s: %v is not one of %v", path, value, schema.Enum)}
+	}
+
+	switch schema.Type {
+	case "":
+		return nil
+	case "null":
+		if value != nil {
+			return []string{fmt.Sprintf("%s: expected null, got %s", path, jsonType(value))}
+		}
+		return nil
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{typeProblem(path, schema.Type, value)}
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return []string{typeProblem(path, schema.Type, value)}
+		}
+		if _, err := n.Int64(); err != nil {
+			return []string{fmt.Sprintf("%s: expected integer, got %s", path, n)}
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return []string{typeProblem(path, schema.Type, value)}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return []string{typeProblem(path, schema.Type, value)}
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return []string{fmt.Sprintf("%s: %q is not a date-time", path, s)}
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return []string{typeProblem(path, schema.Type, value)}
+		}
+		if schema.Items == nil {
+			return nil
+		}
+		var problems []string
+		for i, item := range items {
+			problems = append(problems, d.validateValue(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return problems
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []string{typeProblem(path, schema.Type, value)}
+		}
+		return d.validateObject(schema, object, path)
+	}
+
+	return nil
+}
+
+// validateObject returns the problems found validating the members of object.
+func (d *Document) validateObject(schema *Schema, object map[string]any, path string) []string {
+	var problems []string
+
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: required property %q is missing", path, name))
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			property = schema.AdditionalProperties
+		}
+		if property == nil {
+			continue
+		}
+		problems = append(problems, d.validateValue(property, object[name], path+"."+name)...)
+	}
+
+	return problems
+}
+
+// inEnum reports whether value is one of the enum values.
+func inEnum(enum []any, value any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeProblem describes a value not matching the expected type.
+func typeProblem(path string, expected string, value any) string {
+	return fmt.Sprintf("%s: expected %s, got %s", path, expected, jsonType(value))
+}
+
+// jsonType returns the JSON type name of a decoded JSON value.
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return reflect.TypeOf(value).String()
+}
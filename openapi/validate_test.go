@@ -0,0 +1,217 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jhdrn/go-recoil"
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type Item struct {
+	ID    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Owner *User   `json:"owner"`
+	Tags  []Tag   `json:"tags,omitempty"`
+	Price float64 `json:"price"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+func validatingRouter(content any, status int) (*recoil.Router, *recorder) {
+	document := Generate(Info{Title: "Items", Version: "1.0"}, func() []*recoil.Route {
+		router := recoil.NewRouter()
+		Describe(router.Get("/items/{id}", ok), Operation{
+			Responses: []Response{
+				{Status: http.StatusOK, Content: Item{}},
+				{Status: http.StatusNotFound},
+			},
+		})
+		return router.Routes()
+	}())
+	document.Paths["/items/{id}"]["get"].Responses["200"].Headers = map[string]*Header{
+		"X-Version": {Required: true},
+	}
+
+	rec := &recorder{}
+	router := recoil.NewRouter()
+	router.Use(Validate(document, TestReporter(rec)))
+	router.Get("/items/{id}", func(r *http.Request) recoil.Response {
+		return response.NewBuilder().WithStatus(status).WithContent(content).WithHeaderEntry("X-Version", "1")
+	})
+	router.Get("/undocumented", ok)
+
+	return router, rec
+}
+
+func TestValidate(t *testing.T) {
+
+	router, rec := validatingRouter(Item{ID: 1, Name: "item", Tags: []Tag{{Name: "a"}}, Price: 2.5}, http.StatusOK)
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+	assert.Empty(t, rec.errors)
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "1", rw.Header().Get("X-Version"))
+	assert.Equal(t, `{"id":1,"name":"item","owner":null,"tags":[{"name":"a"}],"price":2.5}`, rw.Body.String())
+}
+
+func TestValidateStatusBody(t *testing.T) {
+
+	router, rec := validatingRouter(nil, http.StatusNotFound)
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+	assert.Empty(t, rec.errors)
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+	assert.JSONEq(t, `{"message":"Not Found"}`, rw.Body.String())
+}
+
+func TestValidateNilSlicesAndMaps(t *testing.T) {
+
+	type list struct {
+		Items  []Tag             `json:"items"`
+		Labels map[string]string `json:"labels"`
+	}
+
+	documented := recoil.NewRouter()
+	Describe(documented.Get("/lists", ok), Operation{Responses: []Response{{Status: http.StatusOK, Content: list{}}}})
+	document := Generate(Info{Title: "Lists", Version: "1.0"}, documented.Routes())
+
+	rec := &recorder{}
+	router := recoil.NewRouter()
+	router.Use(Validate(document, TestReporter(rec)))
+	router.Get("/lists", func(r *http.Request) recoil.Response {
+		return response.OK().WithContent(list{})
+	})
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/lists", nil))
+
+	assert.Empty(t, rec.errors)
+	assert.Equal(t, `{"items":null,"labels":null}`, rw.Body.String())
+}
+
+func TestValidateNull(t *testing.T) {
+
+	document := &Document{}
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"nickname": {Type: "string"},
+			"email":    {Type: "string", Nullable: true},
+		},
+	}
+
+	assert.Empty(t, document.validateValue(schema, map[string]any{"email": nil}, "$"))
+	assert.Equal(t, []string{"$.nickname: expected string, got null"}, document.validateValue(schema, map[string]any{"nickname": nil}, "$"))
+}
+
+func TestValidateMismatches(t *testing.T) {
+
+	tests := []struct {
+		content any
+		status  int
+		target  string
+		problem string
+	}{
+		{map[string]any{"id": "1", "name": "item", "owner": nil, "price": 1}, http.StatusOK, "/items/1", `$.id: expected integer, got string`},
+		{map[string]any{"id": 1.5, "name": "item", "owner": nil, "price": 1}, http.StatusOK, "/items/1", `$.id: expected integer, got 1.5`},
+		{map[string]any{"id": 1, "owner": nil, "price": 1}, http.StatusOK, "/items/1", `$: required property "name" is missing`},
+		{map[string]any{"id": 1, "name": "item", "owner": map[string]any{"id": 1}, "price": 1}, http.StatusOK, "/items/1", `$.owner: required property "name" is missing`},
+		{map[string]any{"id": 1, "name": "item", "owner": nil, "price": 1, "tags": []any{1}}, http.StatusOK, "/items/1", `$.tags[0]: expected object, got number`},
+		{"gone", http.StatusNotFound, "/items/1", "body is not documented"},
+		{nil, http.StatusTeapot, "/items/1", "status 418 is not documented"},
+		{nil, http.StatusOK, "/undocumented", "no operation documented for GET /undocumented"},
+	}
+
+	for _, test := range tests {
+		router, rec := validatingRouter(test.content, test.status)
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, test.target, nil))
+
+		assert.Len(t, rec.errors, 1, test.problem)
+		if len(rec.errors) == 1 {
+			assert.True(t, strings.HasSuffix(rec.errors[0], test.problem), rec.errors[0])
+		}
+		assert.Equal(t, test.status, rw.Code)
+	}
+}
+
+func TestValidateHead(t *testing.T) {
+
+	router, rec := validatingRouter(map[string]any{"id": "not an integer"}, http.StatusOK)
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest(http.MethodHead, "/items/1", nil))
+
+	assert.Empty(t, rec.errors)
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Empty(t, rw.Body.String())
+
+	router, rec = validatingRouter(nil, http.StatusTeapot)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodHead, "/items/1", nil))
+
+	assert.Len(t, rec.errors, 1)
+}
+
+func TestValidateHeadersAndContentType(t *testing.T) {
+
+	document := &Document{
+		Paths: map[string]PathItem{
+			"/": {"get": &OperationObject{
+				Responses: map[string]*ResponseObject{
+					"2XX": {
+						Headers: map[string]*Header{"Location": {Required: true}},
+						Content: map[string]*MediaType{"application/json": {}},
+					},
+				},
+			}},
+		},
+	}
+
+	var reported error
+	router := recoil.NewRouter()
+	router.Use(Validate(document, func(r *http.Request, err error) {
+		reported = err
+	}))
+	router.Get("/", func(r *http.Request) recoil.Response {
+		return response.NewBuilder(response.WithConfig(response.Config{
+			Formatter: response.XMLFormatter{},
+		})).WithStatus(http.StatusCreated)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var validationErr *ValidationError
+	assert.True(t, errors.As(reported, &validationErr))
+	assert.Equal(t, &ValidationError{
+		Method:  http.MethodGet,
+		Pattern: "/",
+		Status:  http.StatusCreated,
+		Problems: []string{
+			`required header "Location" is missing`,
+			`content type "application/xml" is not documented`,
+		},
+	}, validationErr)
+}
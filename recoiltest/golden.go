@@ -0,0 +1,229 @@
+package recoiltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Update makes MatchGolden write golden files instead of comparing with them.
+// It is also enabled by setting the RECOILTEST_UPDATE environment variable to
+// a non-empty value. Tests defining their own -update flag can set it:
+//
+//	var update = flag.Bool("update", false, "update golden files")
+//
+//	func TestMain(m *testing.M) {
+//		flag.Parse()
+//		recoiltest.Update = *update
+//		os.Exit(m.Run())
+//	}
+var Update bool
+
+// updateEnv is the environment variable enabling Update.
+const updateEnv = "RECOILTEST_UPDATE"
+
+// goldenDir is the directory containing golden files.
+var goldenDir = "testdata"
+
+// TestingT is the subset of testing.TB used by MatchGolden.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Snapshot returns the recorded response as text: the status line, the headers
+// sorted by name and the body. JSON, XML and HTML bodies are normalized so the
+// snapshot does not depend on their formatting. As the length of the body
+// depends on its formatting, the Content-Length header is left out.
+func (rec *Recorder) Snapshot() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "HTTP/1.1 %d %s\n", rec.Code, http.StatusText(rec.Code))
+
+	header := rec.Header()
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		if key != "Content-Length" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range header[key] {
+			fmt.Fprintf(&b, "%s: %s\n", key, value)
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(normalizeBody(header.Get("Content-Type"), rec.Body.Bytes()))
+	return b.String()
+}
+
+// MatchGolden compares the snapshot of the recorded response with the golden
+// file testdata/<name>.golden and reports a diff on mismatch. When Update is
+// enabled, the golden file is written instead:
+//
+//	RECOILTEST_UPDATE=1 go test ./...
+func (rec *Recorder) MatchGolden(t TestingT, name string) {
+	t.Helper()
+
+	path := filepath.Join(goldenDir, name+".golden")
+	snapshot := rec.Snapshot()
+
+	if Update || os.Getenv(updateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("failed to create golden file directory: %v", err)
+			return
+		}
+		if err := os.WriteFile(path, []byte(snapshot), 0o644); err != nil {
+			t.Errorf("failed to write golden file: %v", err)
+		}
+		return
+	}
+
+	golden, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("golden file %s does not exist, set %s=1 to create it", path, updateEnv)
+		return
+	}
+	if err != nil {
+		t.Errorf("failed to read golden file: %v", err)
+		return
+	}
+
+	if string(golden) != snapshot {
+		t.Errorf("response does not match golden file %s (-golden +actual):\n%s", path, diff(string(golden), snapshot))
+	}
+}
+
+// normalizeBody returns the body formatted consistently for its media type.
+// Bodies that cannot be parsed are returned as is.
+func normalizeBody(contentType string, body []byte) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, body, "", "  "); err == nil {
+			return indented.String() + "\n"
+		}
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		if normalized, err := normalizeXML(body); err == nil {
+			return normalized
+		}
+	case mediaType == "text/html":
+		return normalizeHTML(body)
+	}
+
+	return string(body)
+}
+
+// normalizeXML indents the XML document, dropping whitespace between elements.
+func normalizeXML(body []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+
+	var b strings.Builder
+	encoder := xml.NewEncoder(&b)
+	encoder.Indent("", "  ")
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if data, ok := token.(xml.CharData); ok {
+			if len(bytes.TrimSpace(data)) == 0 {
+				continue
+			}
+		}
+		if err := encoder.EncodeToken(xml.CopyToken(token)); err != nil {
+			return "", err
+		}
+		if _, ok := token.(xml.ProcInst); ok {
+			// The encoder does not indent after processing instructions.
+			if err := encoder.Flush(); err != nil {
+				return "", err
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return "", err
+	}
+	return b.String() + "\n", nil
+}
+
+var htmlTagBoundary = regexp.MustCompile(`>\s+<`)
+
+// normalizeHTML puts each tag on its own line and trims the lines, removing
+// indentation and blank lines.
+func normalizeHTML(body []byte) string {
+	html := htmlTagBoundary.ReplaceAllString(string(body), ">\n<")
+
+	var b strings.Builder
+	for _, line := range strings.Split(html, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// diff returns a line diff of a and b, prefixing removed lines with "-" and
+// added lines with "+".
+func diff(a string, b string) string {
+	x := strings.Split(a, "\n")
+	y := strings.Split(b, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and
+	// y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			fmt.Fprintf(&out, "  %s\n", x[i])
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&out, "- %s\n", x[i])
+			i++
+		default:
+			fmt.Fprintf(&out, "+ %s\n", y[j])
+			j++
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,100 @@
+package recoiltest
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+
+	rec := Do(userHandler(response.JSONFormatter{}), NewRequest(http.MethodGet, "/").Request())
+	rec.Header().Add("X-B", "2")
+	rec.Header().Add("X-A", "1")
+
+	assert.Equal(t, "HTTP/1.1 201 Created\nContent-Type: application/json\nX-A: 1\nX-B: 2\n\n{\n  \"id\": 1,\n  \"name\": \"Jane\"\n}\n", rec.Snapshot())
+}
+
+func TestNormalizeBody(t *testing.T) {
+
+	tests := []struct {
+		contentType string
+		body        string
+		expected    string
+	}{
+		{"application/vnd.api+json", `{"data":[1, 2]}`, "{\n  \"data\": [\n    1,\n    2\n  ]\n}\n"},
+		{"application/xml; charset=utf-8", "<user>\n <id>1</id><name>Jane</name>\n</user>", "<user>\n  <id>1</id>\n  <name>Jane</name>\n</user>\n"},
+		{"text/html; charset=utf-8", "<ul>\n\t\t<li>a</li>  <li>b</li>\n\n</ul>", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+		{"application/json", `{invalid`, `{invalid`},
+		{"text/plain", "  text  ", "  text  "},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, normalizeBody(test.contentType, []byte(test.body)), test.contentType)
+	}
+}
+
+func TestMatchGolden(t *testing.T) {
+
+	rec := Do(userHandler(response.XMLFormatter{}), NewRequest(http.MethodGet, "/").Request())
+
+	rec.MatchGolden(t, "user")
+}
+
+func TestMatchGoldenMismatch(t *testing.T) {
+
+	rec := Do(userHandler(response.JSONFormatter{}), NewRequest(http.MethodGet, "/").Request())
+
+	failing := &recordingT{}
+	rec.MatchGolden(failing, "user")
+	rec.MatchGolden(failing, "missing")
+
+	assert.Len(t, failing.errors, 2)
+	assert.Contains(t, failing.errors[0], "- Content-Type: application/xml\n+ Content-Type: application/json\n")
+	assert.Contains(t, failing.errors[1], "set RECOILTEST_UPDATE=1 to create it")
+}
+
+func TestMatchGoldenUpdate(t *testing.T) {
+
+	defer func(dir string) {
+		goldenDir = dir
+	}(goldenDir)
+	goldenDir = t.TempDir()
+	t.Setenv("RECOILTEST_UPDATE", "1")
+
+	rec := Do(userHandler(response.JSONFormatter{}), NewRequest(http.MethodGet, "/").Request())
+
+	failing := &recordingT{}
+	rec.MatchGolden(failing, "nested/user")
+	assert.Empty(t, failing.errors)
+
+	golden, err := os.ReadFile(filepath.Join(goldenDir, "nested", "user.golden"))
+	assert.NoError(t, err)
+	assert.Equal(t, rec.Snapshot(), string(golden))
+
+	t.Setenv("RECOILTEST_UPDATE", "")
+	rec.MatchGolden(failing, "nested/user")
+	assert.Empty(t, failing.errors)
+
+	defer func(updating bool) {
+		Update = updating
+	}(Update)
+	Update = true
+
+	rec = Do(userHandler(response.XMLFormatter{}), NewRequest(http.MethodGet, "/").Request())
+	rec.MatchGolden(failing, "nested/user")
+	assert.Empty(t, failing.errors)
+
+	golden, err = os.ReadFile(filepath.Join(goldenDir, "nested", "user.golden"))
+	assert.NoError(t, err)
+	assert.Equal(t, rec.Snapshot(), string(golden))
+}
+
+func TestDiff(t *testing.T) {
+
+	assert.Equal(t, "  a\n- b\n+ x\n  c\n+ d\n", diff("a\nb\nc", "a\nx\nc\nd"))
+}
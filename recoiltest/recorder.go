@@ -0,0 +1,136 @@
+// Package recoiltest provides utilities for testing recoil handlers.
+package recoiltest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Recorder is the recorded response of a handler.
+type Recorder struct {
+	*httptest.ResponseRecorder
+}
+
+// Do serves the request using h and returns the recorded response.
+func Do(h http.Handler, r *http.Request) *Recorder {
+	rec := &Recorder{ResponseRecorder: httptest.NewRecorder()}
+	h.ServeHTTP(rec, r)
+	return rec
+}
+
+// Status returns the recorded status code.
+func (rec *Recorder) Status() int {
+	return rec.Code
+}
+
+// BodyString returns the recorded body.
+func (rec *Recorder) BodyString() string {
+	return rec.Body.String()
+}
+
+// DecodeJSON decodes the recorded JSON body into v.
+func (rec *Recorder) DecodeJSON(v any) error {
+	return json.Unmarshal(rec.Body.Bytes(), v)
+}
+
+// DecodeXML decodes the recorded XML body into v.
+func (rec *Recorder) DecodeXML(v any) error {
+	return xml.Unmarshal(rec.Body.Bytes(), v)
+}
+
+// JSON decodes the recorded JSON body into a value of type T:
+//
+//	user, err := recoiltest.JSON[User](rec)
+func JSON[T any](rec *Recorder) (T, error) {
+	var v T
+	err := rec.DecodeJSON(&v)
+	return v, err
+}
+
+// XML decodes the recorded XML body into a value of type T.
+func XML[T any](rec *Recorder) (T, error) {
+	var v T
+	err := rec.DecodeXML(&v)
+	return v, err
+}
+
+// Assert returns fluent assertions on the recorded response, reporting
+// failures to t:
+//
+//	recoiltest.Do(handler, r).Assert(t).
+//		Status(http.StatusOK).
+//		ContentType("application/json").
+//		JSON(`{"id":1}`)
+func (rec *Recorder) Assert(t assert.TestingT) *Assertions {
+	return &Assertions{t: t, rec: rec}
+}
+
+// Assertions are fluent assertions on a recorded response.
+type Assertions struct {
+	t   assert.TestingT
+	rec *Recorder
+}
+
+// Status asserts the status code of the response.
+func (a *Assertions) Status(status int) *Assertions {
+	a.helper()
+	assert.Equal(a.t, status, a.rec.Code, "unexpected status")
+	return a
+}
+
+// Header asserts the value of the header key of the response.
+func (a *Assertions) Header(key string, value string) *Assertions {
+	a.helper()
+	assert.Equal(a.t, value, a.rec.Header().Get(key), "unexpected %s header", key)
+	return a
+}
+
+// ContentType asserts the Content-Type header of the response.
+func (a *Assertions) ContentType(contentType string) *Assertions {
+	a.helper()
+	return a.Header("Content-Type", contentType)
+}
+
+// Body asserts the body of the response.
+func (a *Assertions) Body(body string) *Assertions {
+	a.helper()
+	assert.Equal(a.t, body, a.rec.Body.String(), "unexpected body")
+	return a
+}
+
+// BodyContains asserts that the body of the response contains s.
+func (a *Assertions) BodyContains(s string) *Assertions {
+	a.helper()
+	assert.Contains(a.t, a.rec.Body.String(), s, "unexpected body")
+	return a
+}
+
+// JSON asserts that the body of the response is JSON equivalent to expected,
+// ignoring formatting and member order. Expected is either a JSON string or a
+// value marshaled to JSON.
+func (a *Assertions) JSON(expected any) *Assertions {
+	a.helper()
+
+	expectedJSON, ok := expected.(string)
+	if !ok {
+		data, err := json.Marshal(expected)
+		if !assert.NoError(a.t, err, "failed to marshal expected JSON") {
+			return a
+		}
+		expectedJSON = string(data)
+	}
+
+	assert.JSONEq(a.t, expectedJSON, a.rec.Body.String(), "unexpected JSON body")
+	return a
+}
+
+// helper marks the calling assertion as a test helper.
+func (a *Assertions) helper() {
+	if h, ok := a.t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+}
@@ -0,0 +1,85 @@
+package recoiltest
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jhdrn/go-recoil"
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	ID   int    `json:"id" xml:"id"`
+	Name string `json:"name" xml:"name"`
+}
+
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func userHandler(formatter response.Formatter) recoil.Handler {
+	return func(r *http.Request) recoil.Response {
+		return response.NewBuilder(response.WithConfig(response.Config{Formatter: formatter})).
+			WithStatus(http.StatusCreated).
+			WithContent(user{ID: 1, Name: "Jane"})
+	}
+}
+
+func TestDo(t *testing.T) {
+
+	rec := Do(userHandler(response.JSONFormatter{}), NewRequest(http.MethodGet, "/").Request())
+
+	assert.Equal(t, http.StatusCreated, rec.Status())
+	assert.Equal(t, `{"id":1,"name":"Jane"}`, rec.BodyString())
+
+	u, err := JSON[user](rec)
+	assert.NoError(t, err)
+	assert.Equal(t, user{ID: 1, Name: "Jane"}, u)
+
+	m, err := JSON[map[string]any](rec)
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", m["name"])
+}
+
+func TestXML(t *testing.T) {
+
+	rec := Do(userHandler(response.XMLFormatter{}), NewRequest(http.MethodGet, "/").Request())
+
+	u, err := XML[user](rec)
+	assert.NoError(t, err)
+	assert.Equal(t, user{ID: 1, Name: "Jane"}, u)
+
+	_, err = JSON[user](rec)
+	assert.Error(t, err)
+}
+
+func TestAssertions(t *testing.T) {
+
+	rec := Do(userHandler(response.JSONFormatter{}), NewRequest(http.MethodGet, "/").Request())
+
+	rec.Assert(t).
+		Status(http.StatusCreated).
+		ContentType("application/json").
+		Body(`{"id":1,"name":"Jane"}`).
+		BodyContains(`"Jane"`).
+		JSON(`{"name": "Jane", "id": 1}`).
+		JSON(user{ID: 1, Name: "Jane"})
+
+	failing := &recordingT{}
+	rec.Assert(failing).
+		Status(http.StatusOK).
+		Header("X-Missing", "value").
+		Body("other").
+		BodyContains("John").
+		JSON(user{ID: 2})
+
+	assert.Len(t, failing.errors, 5)
+}
@@ -0,0 +1,99 @@
+package recoiltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/jhdrn/go-recoil"
+)
+
+// RequestBuilder builds requests for testing handlers:
+//
+//	r := recoiltest.NewRequest(http.MethodPost, "/users/42/posts").
+//		WithParam("id", "42").
+//		WithJSON(post).
+//		Request()
+type RequestBuilder struct {
+	method string
+	target string
+	body   io.Reader
+	header http.Header
+	query  map[string][]string
+	params map[string]string
+}
+
+// NewRequest returns a builder for a request with the given method and target.
+func NewRequest(method string, target string) *RequestBuilder {
+	return &RequestBuilder{
+		method: method,
+		target: target,
+		header: http.Header{},
+		query:  map[string][]string{},
+		params: map[string]string{},
+	}
+}
+
+// WithBody sets the body of the request.
+func (b *RequestBuilder) WithBody(body io.Reader) *RequestBuilder {
+	b.body = body
+	return b
+}
+
+// WithJSON sets the body of the request to v marshaled as JSON, and the
+// Content-Type header to "application/json". Will panic if v cannot be
+// marshaled.
+func (b *RequestBuilder) WithJSON(v any) *RequestBuilder {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal JSON data: %w", err))
+	}
+	b.body = bytes.NewReader(data)
+	return b.WithHeader("Content-Type", "application/json")
+}
+
+// WithHeader adds a header value to the request.
+func (b *RequestBuilder) WithHeader(key string, value string) *RequestBuilder {
+	b.header.Add(key, value)
+	return b
+}
+
+// WithQuery adds a query parameter value to the request.
+func (b *RequestBuilder) WithQuery(key string, value string) *RequestBuilder {
+	b.query[key] = append(b.query[key], value)
+	return b
+}
+
+// WithParam sets a path parameter of the request, as read by recoil.Param.
+func (b *RequestBuilder) WithParam(name string, value string) *RequestBuilder {
+	b.params[name] = value
+	return b
+}
+
+// Request returns the built request.
+func (b *RequestBuilder) Request() *http.Request {
+	r := httptest.NewRequest(b.method, b.target, b.body)
+
+	for key, values := range b.header {
+		r.Header[key] = append(r.Header[key], values...)
+	}
+
+	if len(b.query) > 0 {
+		query := r.URL.Query()
+		for key, values := range b.query {
+			for _, value := range values {
+				query.Add(key, value)
+			}
+		}
+		r.URL.RawQuery = query.Encode()
+	}
+
+	if len(b.params) > 0 {
+		r = recoil.WithParams(r, b.params)
+	}
+
+	return r
+}
@@ -0,0 +1,45 @@
+package recoiltest
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jhdrn/go-recoil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRequest(t *testing.T) {
+
+	r := NewRequest(http.MethodPost, "/users/42/posts?sort=asc").
+		WithParam("id", "42").
+		WithQuery("tag", "a").
+		WithQuery("tag", "b").
+		WithHeader("Accept", "application/json").
+		WithJSON(map[string]string{"title": "Hello"}).
+		Request()
+
+	body, err := io.ReadAll(r.Body)
+	assert.NoError(t, err, "failed to read body reader")
+
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, "/users/42/posts", r.URL.Path)
+	assert.Equal(t, "asc", r.URL.Query().Get("sort"))
+	assert.Equal(t, []string{"a", "b"}, r.URL.Query()["tag"])
+	assert.Equal(t, "application/json", r.Header.Get("Accept"))
+	assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	assert.Equal(t, `{"title":"Hello"}`, string(body))
+	assert.Equal(t, "42", recoil.Param(r, "id"))
+}
+
+func TestNewRequestWithBody(t *testing.T) {
+
+	r := NewRequest(http.MethodPut, "/").WithBody(strings.NewReader("text")).Request()
+
+	body, err := io.ReadAll(r.Body)
+	assert.NoError(t, err, "failed to read body reader")
+
+	assert.Equal(t, "text", string(body))
+	assert.Equal(t, "", recoil.Param(r, "id"))
+}
@@ -3,13 +3,17 @@ package response
 import (
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // DefaultConfig is the default configuration for a response builder. It uses
-// the JSONFormatter to format responses, but can be modified to use a different
-// formatter.
+// the JSONFormatter to format responses and makes cookies HttpOnly, Secure and
+// SameSite=Lax, but can be modified to use a different formatter or cookie
+// defaults.
 var DefaultConfig = Config{
 	Formatter: JSONFormatter{},
+	Cookies:   SecureCookieConfig(),
 }
 
 // ResponseData contains the data to be used to format a response.
@@ -20,6 +24,12 @@ type ResponseData struct {
 	Header http.Header
 	// Status is the HTTP status code to be written to the response.
 	Status int
+	// Template is the name of the template used to format the response, for
+	// formatters that support named templates.
+	Template string
+	// Request is the request being responded to. It may be nil if the
+	// response has not been bound to a request.
+	Request *http.Request
 }
 
 // Formatter is an interface that defines the methods used to format a response.
@@ -29,9 +39,20 @@ type Formatter interface {
 	FormatStatus(ResponseData) int
 }
 
+// ContentLengthFormatter is implemented by formatters that can determine the
+// length of the formatted body without formatting it.
+type ContentLengthFormatter interface {
+	ContentLength(ResponseData) (int64, bool)
+}
+
 // Config contains the configuration for a response builder.
 type Config struct {
 	Formatter Formatter
+	// Cookies contains the defaults applied to cookies set on responses and
+	// the keys for signed and encrypted cookies. If nil, the defaults of
+	// SecureCookieConfig are applied without keys. Use &CookieConfig{} to set
+	// cookies as given.
+	Cookies *CookieConfig
 }
 
 // Builder is a builder for creating responses. It implements the
@@ -39,6 +60,15 @@ type Config struct {
 type Builder struct {
 	config       Config
 	responseData ResponseData
+	trailers     []trailer
+	earlyHints   []string
+}
+
+// trailer is a trailer field with its value computed after the body has been
+// written.
+type trailer struct {
+	key   string
+	value func() string
 }
 
 // Option is a functional option for configuring a response builder.
@@ -74,18 +104,68 @@ func NewBuilder(options ...Option) Builder {
 	return builder
 }
 
-// Body returns the content to be written to the response.
+// Body returns the content to be written to the response. Responses with a
+// status that does not allow a body, like 204 No Content and 304 Not Modified,
+// have an empty body regardless of the formatter.
 func (r Builder) Body() io.Reader {
+	if !BodyAllowed(r.Status()) {
+		return http.NoBody
+	}
 	return r.config.Formatter.FormatBody(r.responseData)
 }
 
+// ContentLength returns the length of the body if it can be determined without
+// formatting the body, which requires the formatter to implement
+// ContentLengthFormatter. Responses with a status that does not allow a body
+// report no length.
+func (r Builder) ContentLength() (int64, bool) {
+	if !BodyAllowed(r.Status()) {
+		return 0, false
+	}
+	if formatter, ok := r.config.Formatter.(ContentLengthFormatter); ok {
+		return formatter.ContentLength(r.responseData)
+	}
+	return 0, false
+}
+
+// Close closes the content of the response if it is an io.Closer, such as a
+// stream set using WithStream. It releases the content of responses whose body
+// is not written, like responses to HEAD requests.
+func (r Builder) Close() error {
+	if closer, ok := r.responseData.Content.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 func (r Builder) Content() any {
 	return r.responseData.Content
 }
 
-// Header returns the header map.
+// Data returns a copy of the data used to format the response. The header map
+// is cloned, so modifying it does not affect the response.
+func (r Builder) Data() ResponseData {
+	data := r.responseData
+	data.Header = cloneHeader(data.Header)
+	return data
+}
+
+// Formatter returns the formatter used to format the response.
+func (r Builder) Formatter() Formatter {
+	return r.config.Formatter
+}
+
+// Header returns the header map. The formatter is given a copy of the header,
+// so the returned map can be modified without affecting the response.
 func (r Builder) Header() http.Header {
-	return r.config.Formatter.FormatHeader(r.responseData)
+	responseData := r.responseData
+	responseData.Header = cloneHeader(responseData.Header)
+	header := r.config.Formatter.FormatHeader(responseData)
+	if !BodyAllowed(r.Status()) {
+		header.Del("Content-Type")
+		header.Del("Content-Length")
+	}
+	return header
 }
 
 // Status returns the HTTP status code. If no status code has been set,
@@ -108,111 +188,144 @@ func (r Builder) WithStream(stream io.Reader) Builder {
 	return r
 }
 
-// WithHeader returns a copy of the response with the given header. It will
-// replace the existing header.
+// WithHeader returns a copy of the response with a copy of the given header.
+// It will replace the existing header.
 func (r Builder) WithHeader(header http.Header) Builder {
-	r.responseData.Header = header
+	r.responseData.Header = cloneHeader(header)
 	return r
 }
 
 // WithHeaderEntry returns a copy of the response with the given header entry.
 // If a header entry with the same key already exists, the existing values will
 // be replaced.
+//
+// The header is copied on write, so the response it is called on is not
+// modified. This makes it safe to derive responses from a shared builder
+// concurrently.
 func (r Builder) WithHeaderEntry(key string, value ...string) Builder {
+	r.responseData.Header = cloneHeader(r.responseData.Header)
 	r.responseData.Header[key] = value
 	return r
 }
 
-// WithStatus returns a copy of the response with the given status.
-func (r Builder) WithStatus(status int) Builder {
-	r.responseData.Status = status
+// WithTrailer returns a copy of the response with the given trailer field.
+// The value function is called after the body has been written, allowing
+// streaming responses to report e.g. a checksum or final status. Trailers
+// require chunked encoding, so the Content-Length header is not set for
+// responses with trailers.
+func (r Builder) WithTrailer(key string, value func() string) Builder {
+	r.trailers = append(append([]trailer{}, r.trailers...), trailer{
+		key:   http.CanonicalHeaderKey(key),
+		value: value,
+	})
 	return r
 }
 
-// WithCookie returns a copy of the response with the given cookie set
-// to the response header.
-func (r Builder) WithCookie(cookie *http.Cookie) Builder {
-	if v := cookie.String(); v != "" {
-		r.WithHeaderEntry("Set-Cookie", v)
+// Trailers returns the trailer fields of the response and the functions
+// computing their values, or nil if there are none.
+func (r Builder) Trailers() map[string]func() string {
+	if len(r.trailers) == 0 {
+		return nil
 	}
-	return r
-}
-
-// BadGateway returns a new response with the status code 502 Bad Gateway.
-func (r Builder) BadGateway() Builder {
-	return r.WithStatus(http.StatusBadGateway)
-}
-
-// BadRequest returns a new response with the status code 400 Bad Request.
-func (r Builder) BadRequest() Builder {
-	return r.WithStatus(http.StatusBadRequest)
-}
-
-// Conflict returns a new response with the status code 409 Conflict.
-func (r Builder) Conflict() Builder {
-	return r.WithStatus(http.StatusConflict)
-}
-
-// Created returns a new response with the status code 201 Created.
-func (r Builder) Created() Builder {
-	return r.WithStatus(http.StatusCreated)
-}
-
-// Forbidden returns a new response with the status code 403 Forbidden.
-func (r Builder) Forbidden() Builder {
-	return r.WithStatus(http.StatusForbidden)
+	trailers := make(map[string]func() string, len(r.trailers))
+	for _, t := range r.trailers {
+		trailers[t.key] = t.value
+	}
+	return trailers
 }
 
-// Found returns a new response with the status code 302 Found
-// Permanently and the given location set as the Location header.
-func (r Builder) Found(location string) Builder {
-	return r.WithStatus(http.StatusFound).WithHeaderEntry("Location", location)
+// WithEarlyHints returns a copy of the response with the given Link header
+// values sent in a 103 Early Hints response before the final response, e.g.
+// "</style.css>; rel=preload; as=style".
+func (r Builder) WithEarlyHints(links ...string) Builder {
+	r.earlyHints = append(append([]string{}, r.earlyHints...), links...)
+	return r
 }
 
-// GatewayTimeout returns a new response with the status code 504 Gateway Timeout.
-func (r Builder) GatewayTimeout() Builder {
-	return r.WithStatus(http.StatusGatewayTimeout)
+// EarlyHints returns the Link header values to send in a 103 Early Hints
+// response.
+func (r Builder) EarlyHints() []string {
+	return r.earlyHints
 }
 
-// Gone returns a new response with the status code 410 Gone.
-func (r Builder) InternalServerError() Builder {
-	return r.WithStatus(http.StatusInternalServerError)
+// WithTemplate returns a copy of the response that will be formatted using the
+// template with the given name.
+func (r Builder) WithTemplate(name string) Builder {
+	r.responseData.Template = name
+	return r
 }
 
-// OK returns a new response with the status code 200 OK.
-func (r Builder) OK() Builder {
-	return r.WithStatus(http.StatusOK)
+// WithRequest returns a copy of the response bound to the given request.
+// Formatters use the request for things like content negotiation.
+// recoil.Handler binds the request being served automatically.
+func (r Builder) WithRequest(request *http.Request) Builder {
+	r.responseData.Request = request
+	return r
 }
 
-// MovedPermanently returns a new response with the status code 301 Moved
-// Permanently and the given location set as the Location header.
-func (r Builder) MovedPermanently(location string) Builder {
-	return r.WithStatus(http.StatusMovedPermanently).WithHeaderEntry("Location", location)
+// Request returns the request the response is bound to, or nil.
+func (r Builder) Request() *http.Request {
+	return r.responseData.Request
 }
 
-// NotFound returns a new response with the status code 404 Not Found.
-func (r Builder) NotFound() Builder {
-	return r.WithStatus(http.StatusNotFound)
+// WithStatus returns a copy of the response with the given status.
+func (r Builder) WithStatus(status int) Builder {
+	r.responseData.Status = status
+	return r
 }
 
-// NotImplemented returns a new response with the status code 501 Not Implemented.
-func (r Builder) NotImplemented() Builder {
-	return r.WithStatus(http.StatusNotImplemented)
+// WithCookie returns a copy of the response with the given cookie added to
+// the Set-Cookie header, after applying the defaults of the configured
+// CookieConfig and the options. Setting several cookies adds a Set-Cookie
+// header for each.
+func (r Builder) WithCookie(cookie *http.Cookie, options ...CookieOption) Builder {
+	if v := r.config.Cookies.apply(cookie, options).String(); v != "" {
+		r.responseData.Header = cloneHeader(r.responseData.Header)
+		r.responseData.Header.Add("Set-Cookie", v)
+	}
+	return r
 }
 
-// PermanentRedirect returns a new response with the status code 308 Permanent Redirect
-// and the given location set as the Location header.
-func (r Builder) PermanentRedirect(location string) Builder {
-	return r.WithStatus(http.StatusPermanentRedirect).WithHeaderEntry("Location", location)
+// withRetryAfter returns a copy of the response with the Retry-After header
+// set to the duration in whole seconds, rounded up. A zero duration leaves the
+// header unset.
+func (r Builder) withRetryAfter(d time.Duration) Builder {
+	if d <= 0 {
+		return r
+	}
+	seconds := (d + time.Second - 1) / time.Second
+	return r.WithHeaderEntry("Retry-After", strconv.FormatInt(int64(seconds), 10))
+}
+
+// BodyAllowed reports whether a response with the status may have a body,
+// which 1xx, 204 No Content, 205 Reset Content and 304 Not Modified responses
+// may not.
+func BodyAllowed(status int) bool {
+	switch {
+	case status < http.StatusOK:
+		return false
+	case status == http.StatusNoContent, status == http.StatusResetContent, status == http.StatusNotModified:
+		return false
+	}
+	return true
 }
 
-// TemporaryRedirect returns a new response with the status code 308 Temporary Redirect
-// and the given location set as the Location header.
-func (r Builder) TemporaryRedirect(location string) Builder {
-	return r.WithStatus(http.StatusTemporaryRedirect).WithHeaderEntry("Location", location)
+// readerLength returns the number of unread bytes of the content if it is an
+// io.Reader reporting its length, like *bytes.Reader and *strings.Reader.
+func readerLength(content any) (int64, bool) {
+	if _, ok := content.(io.Reader); !ok {
+		return 0, false
+	}
+	if l, ok := content.(interface{ Len() int }); ok {
+		return int64(l.Len()), true
+	}
+	return 0, false
 }
 
-// Unauthorized returns a new response with the status code 401 Unauthorized.
-func (r Builder) Unauthorized() Builder {
-	return r.WithStatus(http.StatusUnauthorized)
+// cloneHeader returns a copy of the header, or an empty header if it is nil.
+func cloneHeader(header http.Header) http.Header {
+	if header == nil {
+		return http.Header{}
+	}
+	return header.Clone()
 }
@@ -1,7 +1,14 @@
 package response
 
 import (
+	"errors"
+	"html/template"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,6 +18,7 @@ func TestResponseBuilderWithCookie(t *testing.T) {
 	r := NewBuilder(WithConfig(
 		Config{
 			Formatter: JSONFormatter{},
+			Cookies:   &CookieConfig{},
 		},
 	))
 
@@ -60,3 +68,201 @@ func TestResponseBuilderWithHeaderEntrySingleValue(t *testing.T) {
 
 	assert.Equal(t, h, r.OK().WithHeaderEntry("foo", "bar").Header())
 }
+
+func TestResponseBuilderWithRequest(t *testing.T) {
+	r := NewBuilder()
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.org", nil)
+
+	assert.Nil(t, r.Request())
+	assert.Same(t, request, r.WithRequest(request).Request())
+}
+
+func TestResponseBuilderWithTemplate(t *testing.T) {
+	r := NewBuilder(WithConfig(
+		Config{
+			Formatter: HTMLTemplateFormatter{
+				Template: template.Must(template.New("test").Parse(`{{define "page"}}{{.}}{{end}}`)),
+			},
+		},
+	))
+
+	body, err := io.ReadAll(r.WithTemplate("page").WithContent("value").Body())
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "value", string(body))
+}
+
+func TestResponseBuilderData(t *testing.T) {
+	err := errors.New("failed")
+	r := NewBuilder(WithConfig(
+		Config{
+			Formatter: XMLFormatter{},
+		},
+	)).BadRequest().WithContent(err).WithHeaderEntry("Foo", "bar")
+
+	data := r.Data()
+	data.Header.Set("Foo", "changed")
+
+	assert.Equal(t, err, data.Content)
+	assert.Equal(t, http.StatusBadRequest, data.Status)
+	assert.Equal(t, "bar", r.Data().Header.Get("Foo"))
+	assert.Equal(t, XMLFormatter{}, r.Formatter())
+}
+
+func TestResponseBuilderHeaderIsolation(t *testing.T) {
+	base := NewBuilder(WithConfig(
+		Config{
+			Formatter: XMLFormatter{},
+			Cookies:   &CookieConfig{},
+		},
+	)).WithHeaderEntry("X-Base", "base")
+
+	derived := base.WithHeaderEntry("X-Derived", "derived").WithCookie(&http.Cookie{Name: "foo", Value: "bar"})
+	formatted := derived.Header()
+	formatted.Set("X-Formatted", "formatted")
+
+	assert.Equal(t, http.Header{"X-Base": {"base"}, "Content-Type": {"application/xml"}}, base.Header())
+	assert.Equal(t, "derived", derived.Header().Get("X-Derived"))
+	assert.Equal(t, "foo=bar", derived.Header().Get("Set-Cookie"))
+	assert.Empty(t, derived.Header().Get("X-Formatted"))
+
+	h := http.Header{"X-Foo": {"foo"}}
+	withHeader := base.WithHeader(h)
+	h.Set("X-Foo", "changed")
+
+	assert.Equal(t, "foo", withHeader.Header().Get("X-Foo"))
+	assert.Equal(t, "bar", NewBuilder().WithHeader(nil).WithHeaderEntry("X-Bar", "bar").Header().Get("X-Bar"))
+}
+
+func TestResponseBuilderConcurrentDerivation(t *testing.T) {
+	base := NewBuilder(WithConfig(
+		Config{
+			Formatter: XMLFormatter{},
+			Cookies:   &CookieConfig{},
+		},
+	)).WithHeaderEntry("X-Base", "base")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			value := strconv.Itoa(i)
+			r := base.
+				WithHeaderEntry("X-Request", value).
+				WithCookie(&http.Cookie{Name: "id", Value: value}).
+				WithContent(value)
+
+			header := r.Header()
+			assert.Equal(t, value, header.Get("X-Request"))
+			assert.Equal(t, "id="+value, header.Get("Set-Cookie"))
+			assert.Equal(t, "base", header.Get("X-Base"))
+
+			_, err := io.ReadAll(r.Body())
+			assert.NoError(t, err, "failed to read body reader")
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, http.Header{"X-Base": {"base"}, "Content-Type": {"application/xml"}}, base.Header())
+}
+
+// upperFormatter is a formatter not implementing ContentLengthFormatter, which
+// transforms io.Reader content rather than writing it as is.
+type upperFormatter struct{}
+
+func (f upperFormatter) FormatBody(responseData ResponseData) io.Reader {
+	b, _ := io.ReadAll(responseData.Content.(io.Reader))
+	return strings.NewReader(strings.ToUpper(string(b)))
+}
+
+func (f upperFormatter) FormatHeader(responseData ResponseData) http.Header {
+	return responseData.Header
+}
+
+func (f upperFormatter) FormatStatus(responseData ResponseData) int {
+	return responseData.Status
+}
+
+func TestResponseBuilderContentLength(t *testing.T) {
+	r := NewBuilder()
+
+	length, ok := r.WithStream(strings.NewReader("body")).ContentLength()
+	assert.True(t, ok)
+	assert.Equal(t, int64(4), length)
+
+	_, ok = r.WithContent("body").ContentLength()
+	assert.False(t, ok)
+
+	_, ok = r.NoContent().WithStream(strings.NewReader("body")).ContentLength()
+	assert.False(t, ok)
+
+	_, ok = NewBuilder(WithConfig(Config{Formatter: JSONPFormatter{}})).WithStream(strings.NewReader("body")).ContentLength()
+	assert.False(t, ok)
+
+	_, ok = NewBuilder(WithConfig(Config{Formatter: HTMLTemplateFormatter{}})).WithStream(strings.NewReader("body")).ContentLength()
+	assert.False(t, ok)
+
+	_, ok = NewBuilder(WithConfig(Config{Formatter: upperFormatter{}})).WithStream(strings.NewReader("body")).ContentLength()
+	assert.False(t, ok)
+
+	length, ok = NewBuilder(WithConfig(Config{Formatter: CSVFormatter{}})).WithStream(strings.NewReader("a,b\n")).ContentLength()
+	assert.True(t, ok)
+	assert.Equal(t, int64(4), length)
+
+	negotiating := NewBuilder(WithConfig(Config{Formatter: NegotiatingFormatter{
+		Offers: []MediaTypeFormatter{{MediaType: "application/json", Formatter: JSONFormatter{}}},
+	}}))
+	length, ok = negotiating.WithStream(strings.NewReader("body")).ContentLength()
+	assert.True(t, ok)
+	assert.Equal(t, int64(4), length)
+}
+
+func TestBodyAllowed(t *testing.T) {
+	for _, status := range []int{http.StatusContinue, http.StatusEarlyHints, http.StatusNoContent, http.StatusResetContent, http.StatusNotModified} {
+		assert.False(t, BodyAllowed(status), status)
+	}
+	for _, status := range []int{http.StatusOK, http.StatusCreated, http.StatusNotFound, http.StatusInternalServerError} {
+		assert.True(t, BodyAllowed(status), status)
+	}
+}
+
+func TestResponseBuilderClose(t *testing.T) {
+	r := NewBuilder()
+
+	stream := &closingReader{Reader: strings.NewReader("body")}
+	assert.NoError(t, r.WithStream(stream).Close())
+	assert.True(t, stream.closed)
+
+	assert.NoError(t, r.WithContent("body").Close())
+}
+
+type closingReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closingReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestResponseBuilderTrailersAndEarlyHints(t *testing.T) {
+	r := NewBuilder()
+
+	assert.Nil(t, r.Trailers())
+	assert.Nil(t, r.EarlyHints())
+
+	base := r.WithTrailer("x-foo", func() string { return "foo" }).WithEarlyHints("</a.css>; rel=preload")
+	derived := base.WithTrailer("X-Bar", func() string { return "bar" }).WithEarlyHints("</b.js>; rel=preload")
+
+	assert.Len(t, base.Trailers(), 1)
+	assert.Equal(t, "foo", base.Trailers()["X-Foo"]())
+	assert.Len(t, derived.Trailers(), 2)
+	assert.Equal(t, "bar", derived.Trailers()["X-Bar"]())
+
+	assert.Equal(t, []string{"</a.css>; rel=preload"}, base.EarlyHints())
+	assert.Equal(t, []string{"</a.css>; rel=preload", "</b.js>; rel=preload"}, derived.EarlyHints())
+}
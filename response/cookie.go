@@ -0,0 +1,282 @@
+package response
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCookie is returned when a signed or encrypted cookie cannot be
+// verified or decrypted with any of the configured keys.
+var ErrInvalidCookie = errors.New("invalid cookie value")
+
+// ErrNoCookieKeys is returned when signing or encrypting a cookie without any
+// configured keys.
+var ErrNoCookieKeys = errors.New("no cookie keys configured")
+
+// CookieConfig contains the defaults applied to cookies set on a response, and
+// the keys used for signed and encrypted cookies.
+//
+// The Path, Domain and SameSite defaults are used for cookies which do not set
+// them. HttpOnly and Secure are enabled for all cookies when set, unless a
+// cookie opts out using WithoutHttpOnly or WithoutSecure.
+type CookieConfig struct {
+	Path     string
+	Domain   string
+	HttpOnly bool
+	Secure   bool
+	SameSite http.SameSite
+
+	// Keys are the secrets used to sign and encrypt cookies. The first key is
+	// used to sign and encrypt, while all keys are tried when verifying and
+	// decrypting. Keys can be rotated by prepending a new key and removing the
+	// oldest key once the cookies using it have expired.
+	Keys [][]byte
+}
+
+// SecureCookieConfig returns a CookieConfig making cookies HttpOnly, Secure,
+// SameSite=Lax and valid for all paths, using the given keys.
+func SecureCookieConfig(keys ...[]byte) *CookieConfig {
+	return &CookieConfig{
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Keys:     keys,
+	}
+}
+
+// CookieOption is a functional option overriding the defaults applied to a
+// cookie.
+type CookieOption func(*http.Cookie)
+
+// WithoutHttpOnly opts a cookie out of the HttpOnly default, making it
+// readable by scripts, e.g. for a CSRF double-submit token.
+func WithoutHttpOnly() CookieOption {
+	return func(cookie *http.Cookie) {
+		cookie.HttpOnly = false
+	}
+}
+
+// WithoutSecure opts a cookie out of the Secure default, allowing it to be
+// sent over plain HTTP.
+func WithoutSecure() CookieOption {
+	return func(cookie *http.Cookie) {
+		cookie.Secure = false
+	}
+}
+
+// defaultCookieConfig contains the defaults applied to cookies when a config
+// has no CookieConfig.
+var defaultCookieConfig = SecureCookieConfig()
+
+// apply returns a copy of the cookie with the defaults and then the options
+// applied. A nil config applies the defaults of SecureCookieConfig.
+func (c *CookieConfig) apply(cookie *http.Cookie, options []CookieOption) *http.Cookie {
+	applied := *cookie
+	if c == nil {
+		c = defaultCookieConfig
+	}
+	c.applyDefaults(&applied)
+	for _, opt := range options {
+		opt(&applied)
+	}
+	return &applied
+}
+
+// applyDefaults applies the defaults to the cookie.
+func (c *CookieConfig) applyDefaults(applied *http.Cookie) {
+	if applied.Path == "" {
+		applied.Path = c.Path
+	}
+	if applied.Domain == "" {
+		applied.Domain = c.Domain
+	}
+	if applied.SameSite == 0 {
+		applied.SameSite = c.SameSite
+	}
+	applied.HttpOnly = applied.HttpOnly || c.HttpOnly
+	applied.Secure = applied.Secure || c.Secure
+}
+
+// Sign returns the value signed with the first key. The signature covers the
+// cookie name, so a signed value cannot be moved to another cookie.
+func (c *CookieConfig) Sign(name string, value string) (string, error) {
+	if c == nil || len(c.Keys) == 0 {
+		return "", ErrNoCookieKeys
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(value))
+	mac := cookieMAC(c.Keys[0], name, encoded)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(mac), nil
+}
+
+// Verify returns the value of a signed cookie value if its signature is valid
+// for any of the keys.
+func (c *CookieConfig) Verify(name string, signed string) (string, error) {
+	if c == nil || len(c.Keys) == 0 {
+		return "", ErrNoCookieKeys
+	}
+
+	encoded, signature, ok := strings.Cut(signed, ".")
+	if !ok {
+		return "", ErrInvalidCookie
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	for _, key := range c.Keys {
+		if hmac.Equal(mac, cookieMAC(key, name, encoded)) {
+			value, err := base64.RawURLEncoding.DecodeString(encoded)
+			if err != nil {
+				return "", ErrInvalidCookie
+			}
+			return string(value), nil
+		}
+	}
+	return "", ErrInvalidCookie
+}
+
+// Encrypt returns the value encrypted and authenticated with AES-GCM using the
+// first key. The cookie name is authenticated along with the value.
+func (c *CookieConfig) Encrypt(name string, value string) (string, error) {
+	if c == nil || len(c.Keys) == 0 {
+		return "", ErrNoCookieKeys
+	}
+
+	aead, err := cookieAEAD(c.Keys[0])
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the value of an encrypted cookie value if it can be
+// decrypted with any of the keys.
+func (c *CookieConfig) Decrypt(name string, encrypted string) (string, error) {
+	if c == nil || len(c.Keys) == 0 {
+		return "", ErrNoCookieKeys
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	for _, key := range c.Keys {
+		aead, err := cookieAEAD(key)
+		if err != nil {
+			return "", err
+		}
+		if len(sealed) < aead.NonceSize() {
+			return "", ErrInvalidCookie
+		}
+		value, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(name))
+		if err == nil {
+			return string(value), nil
+		}
+	}
+	return "", ErrInvalidCookie
+}
+
+// SignedCookie returns the verified value of the named signed cookie of r.
+// It returns http.ErrNoCookie if the cookie is missing.
+func (c *CookieConfig) SignedCookie(r *http.Request, name string) (string, error) {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	return c.Verify(name, cookie.Value)
+}
+
+// EncryptedCookie returns the decrypted value of the named encrypted cookie
+// of r. It returns http.ErrNoCookie if the cookie is missing.
+func (c *CookieConfig) EncryptedCookie(r *http.Request, name string) (string, error) {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	return c.Decrypt(name, cookie.Value)
+}
+
+// WithSignedCookie returns a copy of the response with the cookie set, its
+// value signed using the keys of the configured CookieConfig. Will panic if
+// no keys are configured.
+func (r Builder) WithSignedCookie(cookie *http.Cookie, options ...CookieOption) Builder {
+	signed, err := r.config.Cookies.Sign(cookie.Name, cookie.Value)
+	if err != nil {
+		panic(fmt.Errorf("failed to sign cookie: %w", err))
+	}
+
+	c := *cookie
+	c.Value = signed
+	return r.WithCookie(&c, options...)
+}
+
+// WithEncryptedCookie returns a copy of the response with the cookie set, its
+// value encrypted using the keys of the configured CookieConfig. Will panic if
+// no keys are configured.
+func (r Builder) WithEncryptedCookie(cookie *http.Cookie, options ...CookieOption) Builder {
+	encrypted, err := r.config.Cookies.Encrypt(cookie.Name, cookie.Value)
+	if err != nil {
+		panic(fmt.Errorf("failed to encrypt cookie: %w", err))
+	}
+
+	c := *cookie
+	c.Value = encrypted
+	return r.WithCookie(&c, options...)
+}
+
+// DeleteCookie returns a copy of the response with a cookie set that makes
+// the client delete the named cookie. The path and domain of the cookie must
+// match the cookie being deleted, which they do when both use the configured
+// defaults.
+func (r Builder) DeleteCookie(name string) Builder {
+	return r.WithCookie(&http.Cookie{
+		Name:    name,
+		Expires: time.Unix(0, 0),
+		MaxAge:  -1,
+	})
+}
+
+// cookieMAC returns the HMAC-SHA256 of the cookie name and encoded value.
+func cookieMAC(key []byte, name string, encoded string) []byte {
+	mac := hmac.New(sha256.New, deriveCookieKey(key, "sign"))
+	mac.Write([]byte(name + "=" + encoded))
+	return mac.Sum(nil)
+}
+
+// cookieAEAD returns the AES-256-GCM cipher for the key.
+func cookieAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(deriveCookieKey(key, "encrypt"))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// deriveCookieKey derives a 32 byte key for the purpose from the secret, so
+// secrets of any length can be used, and signing and encryption use
+// different keys.
+func deriveCookieKey(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("recoil cookie " + purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,165 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseBuilderMultipleCookies(t *testing.T) {
+	r := NewBuilder(WithConfig(
+		Config{
+			Formatter: JSONFormatter{},
+			Cookies:   &CookieConfig{},
+		},
+	))
+
+	res := r.OK().
+		WithCookie(&http.Cookie{Name: "foo", Value: "bar"}).
+		WithCookie(&http.Cookie{Name: "baz", Value: "qux"})
+
+	assert.Equal(t, []string{"foo=bar", "baz=qux"}, res.Header().Values("Set-Cookie"))
+	assert.Empty(t, r.Header().Values("Set-Cookie"))
+}
+
+func TestResponseBuilderCookieDefaults(t *testing.T) {
+	r := NewBuilder()
+
+	res := r.WithCookie(&http.Cookie{Name: "foo", Value: "bar"}).
+		WithCookie(&http.Cookie{Name: "baz", Value: "qux", Path: "/baz", SameSite: http.SameSiteStrictMode}).
+		WithCookie(&http.Cookie{Name: "csrf", Value: "token"}, WithoutHttpOnly()).
+		WithCookie(&http.Cookie{Name: "dev", Value: "1"}, WithoutHttpOnly(), WithoutSecure())
+
+	assert.Equal(t, []string{
+		"foo=bar; Path=/; HttpOnly; Secure; SameSite=Lax",
+		"baz=qux; Path=/baz; HttpOnly; Secure; SameSite=Strict",
+		"csrf=token; Path=/; Secure; SameSite=Lax",
+		"dev=1; Path=/; SameSite=Lax",
+	}, res.Header().Values("Set-Cookie"))
+
+	withoutCookies := NewBuilder(WithConfig(Config{Formatter: XMLFormatter{}}))
+	assert.Equal(t, "foo=bar; Path=/; HttpOnly; Secure; SameSite=Lax",
+		withoutCookies.WithCookie(&http.Cookie{Name: "foo", Value: "bar"}).Header().Get("Set-Cookie"))
+}
+
+func TestResponseBuilderDeleteCookie(t *testing.T) {
+	r := NewBuilder(WithConfig(
+		Config{
+			Formatter: JSONFormatter{},
+			Cookies:   &CookieConfig{Path: "/app"},
+		},
+	))
+
+	assert.Equal(t, "session=; Path=/app; Expires=Thu, 01 Jan 1970 00:00:00 GMT; Max-Age=0", r.DeleteCookie("session").Header().Get("Set-Cookie"))
+}
+
+func TestCookieConfigSign(t *testing.T) {
+	c := &CookieConfig{Keys: [][]byte{[]byte("secret")}}
+
+	signed, err := c.Sign("session", "user=42; admin")
+	assert.NoError(t, err)
+	assert.NotContains(t, signed, ";")
+
+	value, err := c.Verify("session", signed)
+	assert.NoError(t, err)
+	assert.Equal(t, "user=42; admin", value)
+
+	_, err = c.Verify("other", signed)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = c.Verify("session", strings.Replace(signed, ".", "x.", 1))
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = c.Verify("session", "unsigned")
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = (&CookieConfig{}).Sign("session", "value")
+	assert.ErrorIs(t, err, ErrNoCookieKeys)
+}
+
+func TestCookieConfigEncrypt(t *testing.T) {
+	c := &CookieConfig{Keys: [][]byte{[]byte("a key of any length")}}
+
+	encrypted, err := c.Encrypt("session", "user=42")
+	assert.NoError(t, err)
+	assert.NotContains(t, encrypted, "user")
+
+	other, err := c.Encrypt("session", "user=42")
+	assert.NoError(t, err)
+	assert.NotEqual(t, encrypted, other)
+
+	value, err := c.Decrypt("session", encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "user=42", value)
+
+	_, err = c.Decrypt("other", encrypted)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = c.Decrypt("session", "short")
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = (*CookieConfig)(nil).Decrypt("session", encrypted)
+	assert.ErrorIs(t, err, ErrNoCookieKeys)
+}
+
+func TestCookieConfigKeyRotation(t *testing.T) {
+	old := &CookieConfig{Keys: [][]byte{[]byte("old")}}
+	rotated := &CookieConfig{Keys: [][]byte{[]byte("new"), []byte("old")}}
+	retired := &CookieConfig{Keys: [][]byte{[]byte("new")}}
+
+	signed, _ := old.Sign("session", "value")
+	encrypted, _ := old.Encrypt("session", "value")
+
+	value, err := rotated.Verify("session", signed)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	value, err = rotated.Decrypt("session", encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	_, err = retired.Verify("session", signed)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	_, err = retired.Decrypt("session", encrypted)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+}
+
+func TestResponseBuilderSignedAndEncryptedCookies(t *testing.T) {
+	config := SecureCookieConfig([]byte("secret"))
+	r := NewBuilder(WithConfig(
+		Config{
+			Formatter: JSONFormatter{},
+			Cookies:   config,
+		},
+	))
+
+	res := r.WithSignedCookie(&http.Cookie{Name: "signed", Value: "foo"}).
+		WithEncryptedCookie(&http.Cookie{Name: "encrypted", Value: "bar"})
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	cookies := (&http.Response{Header: res.Header()}).Cookies()
+	assert.Len(t, cookies, 2)
+	for _, cookie := range cookies {
+		assert.True(t, cookie.HttpOnly)
+		request.AddCookie(cookie)
+	}
+
+	value, err := config.SignedCookie(request, "signed")
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+
+	value, err = config.EncryptedCookie(request, "encrypted")
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", value)
+
+	_, err = config.SignedCookie(request, "missing")
+	assert.ErrorIs(t, err, http.ErrNoCookie)
+
+	assert.Panics(t, func() {
+		NewBuilder().WithSignedCookie(&http.Cookie{Name: "foo", Value: "bar"})
+	})
+}
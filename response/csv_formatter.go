@@ -0,0 +1,238 @@
+package response
+
+import (
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// CSVRowFunc is a streaming producer of CSV rows. It is called with a write
+// function that should be invoked once per record. Returning an error aborts
+// the response body with that error.
+type CSVRowFunc func(write func(record []string) error) error
+
+// CSVFormatter is a ResponseFormatter that formats tabular responses as CSV.
+//
+// Supported content is a slice or array of structs (or struct pointers),
+// [][]string and CSVRowFunc. Struct columns are taken from the "csv" struct
+// tag, falling back to the field name. Fields tagged with "-" are skipped.
+type CSVFormatter struct {
+	// Delimiter is the field delimiter. Defaults to ','.
+	Delimiter rune
+	// SkipHeader disables the header row written for slices of structs.
+	SkipHeader bool
+	// BOM prepends a UTF-8 byte order mark, which makes Excel detect the
+	// encoding correctly.
+	BOM bool
+}
+
+// FormatBody formats the response body as CSV. If the response body is nil,
+// it will be set to a single "message" column with the value of
+// http.StatusText(responseData.Status). If the response body is an error, the
+// "message" column will contain the error message. If the response body is an
+// io.Reader, it will be returned as is. Rows are streamed through a pipe, so
+// large results are never held in memory. Will panic if the content type is
+// not supported.
+func (f CSVFormatter) FormatBody(responseData ResponseData) io.Reader {
+
+	var rows CSVRowFunc
+
+	if responseData.Content == nil {
+		rows = csvMessage(http.StatusText(responseData.Status))
+	} else if reader, ok := responseData.Content.(io.Reader); ok {
+		return reader
+	} else if err, ok := responseData.Content.(error); ok {
+		rows = csvMessage(err.Error())
+	} else {
+		var err error
+		rows, err = f.rows(responseData.Content)
+		if err != nil {
+			panic(fmt.Errorf("failed to marshal CSV data: %w", err))
+		}
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+
+	go func() {
+		defer pipeWriter.Close()
+
+		if f.BOM {
+			if _, err := io.WriteString(pipeWriter, "\uFEFF"); err != nil {
+				return
+			}
+		}
+
+		w := csv.NewWriter(pipeWriter)
+		if f.Delimiter != 0 {
+			w.Comma = f.Delimiter
+		}
+
+		err := rows(w.Write)
+		if err == nil {
+			w.Flush()
+			err = w.Error()
+		}
+		if err != nil {
+			pipeWriter.CloseWithError(fmt.Errorf("failed to write CSV data: %w", err))
+		}
+	}()
+
+	return pipeReader
+}
+
+// FormatHeader formats the response header by setting the Content-Type to
+// "text/csv; charset=utf-8".
+func (f CSVFormatter) FormatHeader(responseData ResponseData) http.Header {
+	responseData.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	return responseData.Header
+}
+
+// FormatStatus formats the response status. If the status is 0, it will be
+// set to http.StatusOK.
+func (f CSVFormatter) FormatStatus(responseData ResponseData) int {
+	if responseData.Status == 0 {
+		return http.StatusOK
+	}
+	return responseData.Status
+}
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f CSVFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
+
+// rows returns a CSVRowFunc producing the records of the given content.
+func (f CSVFormatter) rows(content any) (CSVRowFunc, error) {
+	switch c := content.(type) {
+	case CSVRowFunc:
+		return c, nil
+	case func(write func(record []string) error) error:
+		return c, nil
+	case [][]string:
+		return func(write func([]string) error) error {
+			for _, record := range c {
+				if err := write(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	}
+
+	v := reflect.ValueOf(content)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("unsupported content type %T", content)
+	}
+
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported content type %T", content)
+	}
+
+	fields := csvFields(elemType)
+
+	return func(write func([]string) error) error {
+		if !f.SkipHeader {
+			header := make([]string, len(fields))
+			for i, field := range fields {
+				header[i] = field.name
+			}
+			if err := write(header); err != nil {
+				return err
+			}
+		}
+
+		record := make([]string, len(fields))
+		for i := 0; i < v.Len(); i++ {
+			elem := v.Index(i)
+			if elem.Kind() == reflect.Pointer {
+				if elem.IsNil() {
+					continue
+				}
+				elem = elem.Elem()
+			}
+			for j, field := range fields {
+				value, err := csvValue(elem.FieldByIndex(field.index))
+				if err != nil {
+					return fmt.Errorf("field %s: %w", field.name, err)
+				}
+				record[j] = value
+			}
+			if err := write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// csvMessage returns a CSVRowFunc producing a single "message" column.
+func csvMessage(message string) CSVRowFunc {
+	return func(write func([]string) error) error {
+		if err := write([]string{"message"}); err != nil {
+			return err
+		}
+		return write([]string{message})
+	}
+}
+
+type csvField struct {
+	name  string
+	index []int
+}
+
+// csvFields returns the exported fields of t that should be written as CSV
+// columns.
+func csvFields(t reflect.Type) []csvField {
+	var fields []csvField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := sf.Name
+		if tag, ok := sf.Tag.Lookup("csv"); ok {
+			tag, _, _ = strings.Cut(tag, ",")
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		fields = append(fields, csvField{name: name, index: sf.Index})
+	}
+	return fields
+}
+
+// csvValue formats a single struct field value as a CSV cell.
+func csvValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+	}
+
+	switch i := v.Interface().(type) {
+	case encoding.TextMarshaler:
+		text, err := i.MarshalText()
+		return string(text), err
+	case fmt.Stringer:
+		return i.String(), nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		return csvValue(v.Elem())
+	}
+	return fmt.Sprintf("%v", v.Interface()), nil
+}
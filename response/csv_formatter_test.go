@@ -0,0 +1,210 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVFormatterFormatBody(t *testing.T) {
+
+	type csvTest struct {
+		Key     string `csv:"key"`
+		Value   int    `csv:"value"`
+		Ignored string `csv:"-"`
+		Name    *string
+	}
+
+	name := "name"
+
+	responseData := ResponseData{
+		Content: []csvTest{
+			{Key: "a", Value: 1, Ignored: "x", Name: &name},
+			{Key: "b,c", Value: 2},
+		},
+	}
+
+	f := CSVFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "key,value,Name\na,1,name\n\"b,c\",2,\n", string(body))
+}
+
+func TestCSVFormatterFormatBodyStructPointers(t *testing.T) {
+
+	type csvTest struct {
+		Key string `csv:"key"`
+	}
+
+	responseData := ResponseData{
+		Content: []*csvTest{{Key: "a"}, nil, {Key: "b"}},
+	}
+
+	f := CSVFormatter{SkipHeader: true}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "a\nb\n", string(body))
+}
+
+func TestCSVFormatterFormatBodyRecords(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: [][]string{{"a", "b"}, {"1", "2"}},
+	}
+
+	f := CSVFormatter{Delimiter: ';', BOM: true}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "\uFEFFa;b\n1;2\n", string(body))
+}
+
+func TestCSVFormatterFormatBodyRowFunc(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: CSVRowFunc(func(write func([]string) error) error {
+			for _, v := range []string{"1", "2", "3"} {
+				if err := write([]string{v}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	}
+
+	f := CSVFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "1\n2\n3\n", string(body))
+}
+
+func TestCSVFormatterFormatBodyRowFuncError(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: CSVRowFunc(func(write func([]string) error) error {
+			return errors.New("query failed")
+		}),
+	}
+
+	f := CSVFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	_, err := io.ReadAll(bodyReader)
+
+	assert.ErrorContains(t, err, "query failed")
+}
+
+func TestCSVFormatterFormatStream(t *testing.T) {
+
+	body := []byte("body")
+
+	responseData := ResponseData{
+		Content: bytes.NewReader(body),
+	}
+
+	f := CSVFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	responseBody, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, responseBody, body)
+}
+
+func TestCSVFormatterFormatBodyNilContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: nil,
+		Status:  http.StatusNotFound,
+	}
+
+	f := CSVFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "message\nNot Found\n", string(body))
+}
+
+func TestCSVFormatterFormatBodyErrorContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: errors.New("some error"),
+		Status:  http.StatusBadRequest,
+	}
+
+	f := CSVFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "message\nsome error\n", string(body))
+}
+
+func TestCSVFormatterFormatBodyBadContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: map[string]any{},
+	}
+
+	f := CSVFormatter{}
+
+	testPanic := func() {
+		f.FormatBody(responseData)
+	}
+
+	assert.Panics(t, testPanic, "did not panic on bad CSV input type")
+}
+
+func TestCSVFormatterFormatHeader(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	responseData.Header.Set("key", "value")
+
+	f := CSVFormatter{}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "text/csv; charset=utf-8", header.Get("Content-Type"))
+	assert.Equal(t, "value", header.Get("key"))
+	assert.Equal(t, 2, len(header))
+}
+
+func TestCSVFormatterFormatStatusZeroValue(t *testing.T) {
+
+	responseData := ResponseData{}
+
+	f := CSVFormatter{}
+
+	status := f.FormatStatus(responseData)
+
+	assert.Equal(t, http.StatusOK, status)
+}
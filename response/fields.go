@@ -0,0 +1,134 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// FieldSelection is a tree of selected field paths, as used for sparse
+// fieldsets. A nil subtree selects the whole field.
+type FieldSelection map[string]FieldSelection
+
+// ParseFieldSelection parses a comma separated list of field paths, e.g.
+// "id,name,author.name". Nested fields are separated by dots. Selecting a
+// field also selects all of its nested fields.
+func ParseFieldSelection(s string) FieldSelection {
+	selection := FieldSelection{}
+	for _, path := range strings.Split(s, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		selection.add(strings.Split(path, "."))
+	}
+	return selection
+}
+
+// add adds the given path to the selection.
+func (s FieldSelection) add(path []string) {
+	node := s
+	for i, name := range path {
+		child, exists := node[name]
+		if exists && child == nil {
+			return
+		}
+		if i == len(path)-1 {
+			node[name] = nil
+			return
+		}
+		if !exists {
+			child = FieldSelection{}
+			node[name] = child
+		}
+		node = child
+	}
+}
+
+// Apply returns the JSON encoded data pruned to the selected fields. Objects
+// are pruned to the selected members, arrays have the selection applied to
+// each of their elements. The order of the remaining members is preserved.
+func (s FieldSelection) Apply(data []byte) ([]byte, error) {
+	if !json.Valid(data) {
+		return nil, errors.New("invalid JSON data")
+	}
+
+	var buf bytes.Buffer
+	if err := s.prune(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// prune writes the JSON value data pruned to the selected fields to buf.
+func (s FieldSelection) prune(buf *bytes.Buffer, data json.RawMessage) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || (data[0] != '{' && data[0] != '[') {
+		buf.Write(data)
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	if data[0] == '[' {
+		buf.WriteByte('[')
+		for i := 0; decoder.More(); i++ {
+			var element json.RawMessage
+			if err := decoder.Decode(&element); err != nil {
+				return err
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := s.prune(buf, element); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+
+	buf.WriteByte('{')
+	first := true
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := token.(string)
+
+		var member json.RawMessage
+		if err := decoder.Decode(&member); err != nil {
+			return err
+		}
+
+		child, ok := s[name]
+		if !ok {
+			continue
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		if child == nil {
+			buf.Write(member)
+		} else if err := child.prune(buf, member); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFieldSelection(t *testing.T) {
+
+	assert.Equal(t, FieldSelection{}, ParseFieldSelection(""))
+	assert.Equal(t, FieldSelection{
+		"id":     nil,
+		"author": FieldSelection{"name": nil, "address": FieldSelection{"city": nil}},
+	}, ParseFieldSelection(" id, author.name,,author.address.city"))
+	assert.Equal(t, FieldSelection{"author": nil}, ParseFieldSelection("author.name,author"))
+	assert.Equal(t, FieldSelection{"author": nil}, ParseFieldSelection("author,author.name"))
+}
+
+func TestFieldSelectionApply(t *testing.T) {
+
+	selection := ParseFieldSelection("id,author.name,tags")
+
+	data, err := selection.Apply([]byte(`[
+		{"id": 12345678901234567890, "title": "a", "author": {"name": "n", "email": "e"}, "tags": [{"x": 1}]},
+		{"id": 2, "author": null}
+	]`))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"id": 12345678901234567890, "author": {"name": "n"}, "tags": [{"x": 1}]},
+		{"id": 2, "author": null}
+	]`, string(data))
+
+	_, err = selection.Apply([]byte(`{`))
+
+	assert.Error(t, err)
+}
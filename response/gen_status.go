@@ -0,0 +1,179 @@
+//go:build ignore
+
+// gen_status.go generates status.go, containing the Builder methods and
+// package level helpers for all HTTP status codes except 1xx.
+//
+// Run with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"net/http"
+	"os"
+	"text/template"
+)
+
+// kind determines the parameters and semantics of a status helper.
+type kind int
+
+const (
+	plain kind = iota
+	// location statuses take the location set as the Location header.
+	location
+	// monitor statuses take an optional location of a status monitor.
+	monitor
+	// retryAfter statuses take a duration set as the Retry-After header.
+	retryAfter
+)
+
+type status struct {
+	Name string
+	Code int
+	Kind kind
+}
+
+var statuses = []status{
+	{"OK", http.StatusOK, plain},
+	{"Created", http.StatusCreated, plain},
+	{"Accepted", http.StatusAccepted, monitor},
+	{"NonAuthoritativeInfo", http.StatusNonAuthoritativeInfo, plain},
+	{"NoContent", http.StatusNoContent, plain},
+	{"ResetContent", http.StatusResetContent, plain},
+	{"PartialContent", http.StatusPartialContent, plain},
+	{"MultiStatus", http.StatusMultiStatus, plain},
+	{"AlreadyReported", http.StatusAlreadyReported, plain},
+	{"IMUsed", http.StatusIMUsed, plain},
+
+	{"MultipleChoices", http.StatusMultipleChoices, plain},
+	{"MovedPermanently", http.StatusMovedPermanently, location},
+	{"Found", http.StatusFound, location},
+	{"SeeOther", http.StatusSeeOther, location},
+	{"NotModified", http.StatusNotModified, plain},
+	{"TemporaryRedirect", http.StatusTemporaryRedirect, location},
+	{"PermanentRedirect", http.StatusPermanentRedirect, location},
+
+	{"BadRequest", http.StatusBadRequest, plain},
+	{"Unauthorized", http.StatusUnauthorized, plain},
+	{"PaymentRequired", http.StatusPaymentRequired, plain},
+	{"Forbidden", http.StatusForbidden, plain},
+	{"NotFound", http.StatusNotFound, plain},
+	{"MethodNotAllowed", http.StatusMethodNotAllowed, plain},
+	{"NotAcceptable", http.StatusNotAcceptable, plain},
+	{"ProxyAuthRequired", http.StatusProxyAuthRequired, plain},
+	{"RequestTimeout", http.StatusRequestTimeout, plain},
+	{"Conflict", http.StatusConflict, plain},
+	{"Gone", http.StatusGone, plain},
+	{"LengthRequired", http.StatusLengthRequired, plain},
+	{"PreconditionFailed", http.StatusPreconditionFailed, plain},
+	{"RequestEntityTooLarge", http.StatusRequestEntityTooLarge, plain},
+	{"RequestURITooLong", http.StatusRequestURITooLong, plain},
+	{"UnsupportedMediaType", http.StatusUnsupportedMediaType, plain},
+	{"RequestedRangeNotSatisfiable", http.StatusRequestedRangeNotSatisfiable, plain},
+	{"ExpectationFailed", http.StatusExpectationFailed, plain},
+	{"Teapot", http.StatusTeapot, plain},
+	{"MisdirectedRequest", http.StatusMisdirectedRequest, plain},
+	{"UnprocessableEntity", http.StatusUnprocessableEntity, plain},
+	{"Locked", http.StatusLocked, plain},
+	{"FailedDependency", http.StatusFailedDependency, plain},
+	{"TooEarly", http.StatusTooEarly, plain},
+	{"UpgradeRequired", http.StatusUpgradeRequired, plain},
+	{"PreconditionRequired", http.StatusPreconditionRequired, plain},
+	{"TooManyRequests", http.StatusTooManyRequests, retryAfter},
+	{"RequestHeaderFieldsTooLarge", http.StatusRequestHeaderFieldsTooLarge, plain},
+	{"UnavailableForLegalReasons", http.StatusUnavailableForLegalReasons, plain},
+
+	{"InternalServerError", http.StatusInternalServerError, plain},
+	{"NotImplemented", http.StatusNotImplemented, plain},
+	{"BadGateway", http.StatusBadGateway, plain},
+	{"ServiceUnavailable", http.StatusServiceUnavailable, retryAfter},
+	{"GatewayTimeout", http.StatusGatewayTimeout, plain},
+	{"HTTPVersionNotSupported", http.StatusHTTPVersionNotSupported, plain},
+	{"VariantAlsoNegotiates", http.StatusVariantAlsoNegotiates, plain},
+	{"InsufficientStorage", http.StatusInsufficientStorage, plain},
+	{"LoopDetected", http.StatusLoopDetected, plain},
+	{"NotExtended", http.StatusNotExtended, plain},
+	{"NetworkAuthenticationRequired", http.StatusNetworkAuthenticationRequired, plain},
+}
+
+var tmpl = template.Must(template.New("status").Funcs(template.FuncMap{
+	"text": http.StatusText,
+}).Parse(`// Code generated by gen_status.go; DO NOT EDIT.
+
+package response
+
+import (
+	"net/http"
+	"time"
+)
+{{range .}}
+{{- if eq .Kind 1}}
+// {{.Name}} returns a new response with the status code {{.Code}} {{text .Code}}
+// and the given location set as the Location header.
+func (r Builder) {{.Name}}(location string) Builder {
+	return r.WithStatus(http.Status{{.Name}}).WithHeaderEntry("Location", location)
+}
+
+// {{.Name}} returns a {{.Code}} {{text .Code}} Builder using DefaultConfig.
+func {{.Name}}(location string) Builder {
+	return NewBuilder().{{.Name}}(location)
+}
+{{- else if eq .Kind 2}}
+// {{.Name}} returns a new response with the status code {{.Code}} {{text .Code}}
+// and the location of a status monitor set as the Location header, unless the
+// location is empty.
+func (r Builder) {{.Name}}(location string) Builder {
+	r = r.WithStatus(http.Status{{.Name}})
+	if location != "" {
+		r = r.WithHeaderEntry("Location", location)
+	}
+	return r
+}
+
+// {{.Name}} returns a {{.Code}} {{text .Code}} Builder using DefaultConfig.
+func {{.Name}}(location string) Builder {
+	return NewBuilder().{{.Name}}(location)
+}
+{{- else if eq .Kind 3}}
+// {{.Name}} returns a new response with the status code {{.Code}} {{text .Code}}
+// and the Retry-After header set to the given duration, unless it is zero.
+func (r Builder) {{.Name}}(retryAfter time.Duration) Builder {
+	return r.WithStatus(http.Status{{.Name}}).withRetryAfter(retryAfter)
+}
+
+// {{.Name}} returns a {{.Code}} {{text .Code}} Builder using DefaultConfig.
+func {{.Name}}(retryAfter time.Duration) Builder {
+	return NewBuilder().{{.Name}}(retryAfter)
+}
+{{- else}}
+// {{.Name}} returns a new response with the status code {{.Code}} {{text .Code}}.
+func (r Builder) {{.Name}}() Builder {
+	return r.WithStatus(http.Status{{.Name}})
+}
+
+// {{.Name}} returns a {{.Code}} {{text .Code}} Builder using DefaultConfig.
+func {{.Name}}() Builder {
+	return NewBuilder().{{.Name}}()
+}
+{{- end}}
+{{end}}`))
+
+func main() {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, statuses); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile("status.go", src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,183 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// HALFormatter is a ResponseFormatter that formats responses as HAL documents
+// (application/hal+json).
+//
+// Resources implementing HALResource get a "_links" member. Struct fields
+// tagged with "hal" are moved into the "_embedded" member using the tag value
+// as name, and are formatted as HAL resources themselves:
+//
+//	type Order struct {
+//		ID       string    `json:"id"`
+//		Customer *Customer `hal:"customer"`
+//	}
+type HALFormatter struct{}
+
+// HALLink is a HAL link object.
+type HALLink struct {
+	Href      string `json:"href"`
+	Templated bool   `json:"templated,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// HALResource is implemented by resources that provide HAL links.
+type HALResource interface {
+	HALLinks() map[string]HALLink
+}
+
+// FormatBody formats the response body as HAL. If the response body is nil,
+// it will be set to a map with a single key "message" and the value of
+// http.StatusText(responseData.Status). If the response body is an error,
+// the response body will be set to a map with a single key "message" and the
+// value of the error message. If the response body is an io.Reader, it will be
+// returned as is.
+func (f HALFormatter) FormatBody(responseData ResponseData) io.Reader {
+
+	var document any
+
+	if responseData.Content == nil {
+		document = map[string]string{
+			"message": http.StatusText(responseData.Status),
+		}
+	} else if reader, ok := responseData.Content.(io.Reader); ok {
+		return reader
+	} else if err, ok := responseData.Content.(error); ok {
+		document = map[string]string{
+			"message": err.Error(),
+		}
+	} else {
+		var err error
+		document, err = halValue(reflect.ValueOf(responseData.Content))
+		if err != nil {
+			panic(fmt.Errorf("failed to marshal HAL data: %w", err))
+		}
+	}
+
+	jsonBytes, err := json.Marshal(document)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal HAL data: %w", err))
+	}
+
+	return bytes.NewReader(jsonBytes)
+}
+
+// FormatHeader formats the response header by setting the Content-Type to
+// "application/hal+json".
+func (f HALFormatter) FormatHeader(responseData ResponseData) http.Header {
+	responseData.Header.Set("Content-Type", "application/hal+json")
+	return responseData.Header
+}
+
+// FormatStatus formats the response status. If the status is 0, it will be
+// set to http.StatusOK.
+func (f HALFormatter) FormatStatus(responseData ResponseData) int {
+	if responseData.Status == 0 {
+		return http.StatusOK
+	}
+	return responseData.Status
+}
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f HALFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
+
+// halValue returns the value to marshal for v. Structs are converted to HAL
+// objects and slices to slices of HAL values, anything else is returned as is.
+func halValue(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil, nil
+	}
+
+	switch indirect := reflect.Indirect(v); indirect.Kind() {
+	case reflect.Struct:
+		return halObject(v)
+	case reflect.Slice, reflect.Array:
+		if indirect.Kind() == reflect.Slice && indirect.IsNil() {
+			return nil, nil
+		}
+		values := make([]any, indirect.Len())
+		for i := range values {
+			value, err := halValue(indirect.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+
+	return v.Interface(), nil
+}
+
+// halObject returns the HAL object for the struct v.
+func halObject(v reflect.Value) (map[string]any, error) {
+	jsonBytes, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	object := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	if resource, ok := v.Interface().(HALResource); ok {
+		if links := resource.HALLinks(); len(links) > 0 {
+			object["_links"] = links
+		}
+	}
+
+	s := reflect.Indirect(v)
+	embedded := map[string]any{}
+	for i := 0; i < s.NumField(); i++ {
+		sf := s.Type().Field(i)
+		name, ok := sf.Tag.Lookup("hal")
+		if !ok || !sf.IsExported() {
+			continue
+		}
+
+		delete(object, halJSONName(sf))
+
+		value, err := halValue(s.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			embedded[name] = value
+		}
+	}
+	if len(embedded) > 0 {
+		object["_embedded"] = embedded
+	}
+
+	return object, nil
+}
+
+// halJSONName returns the name of the struct field in its JSON encoding.
+func halJSONName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" {
+		return sf.Name
+	}
+	return name
+}
@@ -0,0 +1,146 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type halCustomer struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (c halCustomer) HALLinks() map[string]HALLink {
+	return map[string]HALLink{"self": {Href: "/customers/1"}}
+}
+
+type halOrder struct {
+	ID       int64         `json:"id"`
+	Total    float64       `json:"total"`
+	Customer *halCustomer  `json:"customer" hal:"customer"`
+	Items    []halCustomer `hal:"items"`
+	Missing  *halCustomer  `hal:"missing"`
+}
+
+func (o halOrder) HALLinks() map[string]HALLink {
+	return map[string]HALLink{
+		"self": {Href: "/orders/9007199254740993"},
+		"find": {Href: "/orders{?id}", Templated: true},
+	}
+}
+
+func TestHALFormatterFormatBody(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: halOrder{
+			ID:       9007199254740993,
+			Total:    10.5,
+			Customer: &halCustomer{ID: 1, Name: "Jane"},
+		},
+	}
+
+	f := HALFormatter{}
+
+	body, err := io.ReadAll(f.FormatBody(responseData))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{
+		"id": 9007199254740993,
+		"total": 10.5,
+		"_links": {
+			"self": {"href": "/orders/9007199254740993"},
+			"find": {"href": "/orders{?id}", "templated": true}
+		},
+		"_embedded": {
+			"customer": {"id": 1, "name": "Jane", "_links": {"self": {"href": "/customers/1"}}}
+		}
+	}`, string(body))
+}
+
+func TestHALFormatterFormatBodyCollection(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: []halCustomer{{ID: 1, Name: "Jane"}},
+	}
+
+	f := HALFormatter{}
+
+	body, err := io.ReadAll(f.FormatBody(responseData))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `[{"id": 1, "name": "Jane", "_links": {"self": {"href": "/customers/1"}}}]`, string(body))
+}
+
+func TestHALFormatterFormatStream(t *testing.T) {
+
+	body := []byte("body")
+
+	responseData := ResponseData{
+		Content: bytes.NewReader(body),
+	}
+
+	f := HALFormatter{}
+
+	responseBody, err := io.ReadAll(f.FormatBody(responseData))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, responseBody, body)
+}
+
+func TestHALFormatterFormatBodyNilContent(t *testing.T) {
+
+	f := HALFormatter{}
+
+	body, err := io.ReadAll(f.FormatBody(ResponseData{Status: http.StatusNotFound}))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{"message":"Not Found"}`, string(body))
+}
+
+func TestHALFormatterFormatBodyErrorContent(t *testing.T) {
+
+	f := HALFormatter{}
+
+	body, err := io.ReadAll(f.FormatBody(ResponseData{Content: errors.New("some error")}))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{"message":"some error"}`, string(body))
+}
+
+func TestHALFormatterFormatBodyBadContent(t *testing.T) {
+
+	f := HALFormatter{}
+
+	assert.Panics(t, func() {
+		f.FormatBody(ResponseData{Content: struct{ F func() }{}})
+	})
+}
+
+func TestHALFormatterFormatHeader(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	responseData.Header.Set("key", "value")
+
+	f := HALFormatter{}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "application/hal+json", header.Get("Content-Type"))
+	assert.Equal(t, "value", header.Get("key"))
+	assert.Equal(t, 2, len(header))
+}
+
+func TestHALFormatterFormatStatusZeroValue(t *testing.T) {
+
+	f := HALFormatter{}
+
+	assert.Equal(t, http.StatusOK, f.FormatStatus(ResponseData{}))
+}
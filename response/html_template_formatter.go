@@ -1,26 +1,87 @@
 package response
 
 import (
+	"errors"
 	"fmt"
 	"html/template"
 	"io"
 	"net/http"
 )
 
+// errNoTemplate is returned when reading the body of a response for which no
+// template is selected.
+var errNoTemplate = errors.New("no template selected")
+
 // HTMLTemplateFormatter is a ResponseFormatter that formats responses using Go
 // HTML templates.
+//
+// If the response has a template name set using Builder.WithTemplate, the
+// named template is looked up in Templates, or executed from Template if
+// Templates is nil. Otherwise Template is executed.
 type HTMLTemplateFormatter struct {
-	Template *template.Template
+	Template  *template.Template
+	Templates *TemplateSet
+	// View configures the formatter to execute templates with a View as data
+	// instead of the response content, giving templates access to the request
+	// and request-scoped values.
+	View bool
+	// ErrorTemplates maps status codes to the names of the templates used for
+	// responses with error content, or nil content and an error status. The
+	// templates are executed with an ErrorPage as content.
+	ErrorTemplates map[int]string
+	// ErrorTemplate is the name of the template used for error responses with
+	// a status missing from ErrorTemplates.
+	ErrorTemplate string
+}
+
+// ErrorPage is the content passed to error templates.
+type ErrorPage struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// StatusText is the text for the status code, e.g. "Not Found".
+	StatusText string
+	// Message is the message of the error, see SafeMessager, or the status
+	// text.
+	Message string
+}
+
+// SafeMessager is implemented by errors providing a message that is safe to
+// show on error pages. Error pages show the message of errors implementing it
+// for any status, the message of other errors only for 4xx statuses and the
+// status text otherwise, keeping internal error details out of 5xx pages.
+type SafeMessager interface {
+	SafeMessage() string
 }
 
+// FormatBody executes the template with the response content, or a View if
+// f.View is set, as data. If the response body is an error, or nil with a status
+// of 400 or above, and an error template is configured for the status, the
+// error template is executed with an ErrorPage as content instead. Template
+// lookup and execution errors are returned when reading the body, as is an
+// error if no template is selected.
 func (f HTMLTemplateFormatter) FormatBody(responseData ResponseData) io.Reader {
 
+	if name, page, ok := f.errorPage(responseData); ok {
+		responseData.Template = name
+		responseData.Content = page
+	}
+
 	pipeReader, pipeWriter := io.Pipe()
 
+	if f.Template == nil && (responseData.Template == "" || f.Templates == nil) {
+		pipeWriter.CloseWithError(errNoTemplate)
+		return pipeReader
+	}
+
+	var data any = responseData.Content
+	if f.View {
+		data = newView(responseData)
+	}
+
 	go func() {
 		defer pipeWriter.Close()
 
-		err := f.Template.Execute(pipeWriter, responseData.Content)
+		err := f.execute(pipeWriter, responseData.Template, data)
 		if err != nil {
 			pipeWriter.CloseWithError(fmt.Errorf("failed to execute template: %w", err))
 		}
@@ -29,6 +90,62 @@ func (f HTMLTemplateFormatter) FormatBody(responseData ResponseData) io.Reader {
 	return pipeReader
 }
 
+// errorPage returns the error template name and page for the response, if the
+// response is an error response and an error template is configured.
+func (f HTMLTemplateFormatter) errorPage(responseData ResponseData) (string, ErrorPage, bool) {
+	status := f.FormatStatus(responseData)
+
+	page := ErrorPage{
+		Status:     status,
+		StatusText: http.StatusText(status),
+	}
+
+	if err, ok := responseData.Content.(error); ok {
+		page.Message = safeMessage(err, status)
+	} else if responseData.Content == nil && status >= 400 {
+		page.Message = page.StatusText
+	} else {
+		return "", page, false
+	}
+
+	name, ok := f.ErrorTemplates[status]
+	if !ok {
+		name = f.ErrorTemplate
+	}
+	return name, page, name != ""
+}
+
+// safeMessage returns the message of err to show on the error page of a
+// response with the given status.
+func safeMessage(err error, status int) string {
+	var safe SafeMessager
+	if errors.As(err, &safe) {
+		return safe.SafeMessage()
+	}
+	if status >= 400 && status < 500 {
+		return err.Error()
+	}
+	return http.StatusText(status)
+}
+
+// execute executes the template with the given name, or f.Template if the name
+// is empty.
+func (f HTMLTemplateFormatter) execute(w io.Writer, name string, data any) error {
+	if name == "" {
+		return f.Template.Execute(w, data)
+	}
+
+	if f.Templates == nil {
+		return f.Template.ExecuteTemplate(w, name, data)
+	}
+
+	t, err := f.Templates.Lookup(name)
+	if err != nil {
+		return err
+	}
+	return t.ExecuteTemplate(w, name, data)
+}
+
 func (f HTMLTemplateFormatter) FormatHeader(responseData ResponseData) http.Header {
 	responseData.Header.Set("Content-Type", "text/html")
 	return responseData.Header
@@ -2,6 +2,8 @@ package response
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"html/template"
 	"io"
 	"net/http"
@@ -138,3 +140,127 @@ func TestHTMLTemplateFormatterFormatStatusZeroValue(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, status)
 }
+
+func TestHTMLTemplateFormatterFormatBodyNamedTemplate(t *testing.T) {
+
+	template, err := template.New("test").Parse(
+		`{{define "a"}}<p>a {{.}}</p>{{end}}{{define "b"}}<p>b {{.}}</p>{{end}}`,
+	)
+
+	assert.NoError(t, err, "failed to parse template")
+
+	responseData := ResponseData{
+		Content:  "value",
+		Template: "b",
+	}
+
+	f := HTMLTemplateFormatter{
+		Template: template,
+	}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "<p>b value</p>", string(body))
+}
+
+func TestHTMLTemplateFormatterFormatBodyTemplateSet(t *testing.T) {
+
+	templates, err := NewTemplateSet(templateSetFS(), []string{"layouts/*.html"}, []string{"pages/about.html"})
+
+	assert.NoError(t, err, "failed to load templates")
+
+	f := HTMLTemplateFormatter{
+		Templates: templates,
+	}
+
+	bodyReader := f.FormatBody(ResponseData{Template: "pages/about.html"})
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "<html><title>Default</title><body>About</body></html>", string(body))
+
+	_, err = io.ReadAll(f.FormatBody(ResponseData{Template: "pages/missing.html"}))
+
+	assert.Error(t, err)
+}
+
+func TestHTMLTemplateFormatterFormatBodyNoTemplate(t *testing.T) {
+
+	templates, err := NewTemplateSet(templateSetFS(), []string{"layouts/*.html"}, []string{"pages/about.html"})
+
+	assert.NoError(t, err, "failed to load templates")
+
+	f := HTMLTemplateFormatter{
+		Templates: templates,
+	}
+
+	_, err = io.ReadAll(f.FormatBody(ResponseData{Status: http.StatusNotFound}))
+
+	assert.ErrorIs(t, err, errNoTemplate)
+
+	_, err = io.ReadAll(HTMLTemplateFormatter{}.FormatBody(ResponseData{Template: "page"}))
+
+	assert.ErrorIs(t, err, errNoTemplate)
+}
+
+type safeError struct{}
+
+func (safeError) Error() string       { return "connection refused" }
+func (safeError) SafeMessage() string { return "Try again later" }
+
+func TestHTMLTemplateFormatterFormatBodyErrorTemplates(t *testing.T) {
+
+	template, err := template.New("test").Parse(
+		`{{define "404"}}<h1>{{.StatusText}}</h1>{{end}}` +
+			`{{define "error"}}<h1>{{.Status}} {{.StatusText}}</h1><p>{{.Message}}</p>{{end}}` +
+			`<p>{{.}}</p>`,
+	)
+
+	assert.NoError(t, err, "failed to parse template")
+
+	f := HTMLTemplateFormatter{
+		Template:       template,
+		ErrorTemplates: map[int]string{http.StatusNotFound: "404"},
+		ErrorTemplate:  "error",
+	}
+
+	tests := []struct {
+		responseData ResponseData
+		expected     string
+	}{
+		{ResponseData{Status: http.StatusNotFound}, "<h1>Not Found</h1>"},
+		{ResponseData{Status: http.StatusBadRequest}, "<h1>400 Bad Request</h1><p>Bad Request</p>"},
+		{ResponseData{Status: http.StatusBadRequest, Content: errors.New("<bad> & wrong")}, "<h1>400 Bad Request</h1><p>&lt;bad&gt; &amp; wrong</p>"},
+		{ResponseData{Status: http.StatusInternalServerError, Content: errors.New("connection refused")}, "<h1>500 Internal Server Error</h1><p>Internal Server Error</p>"},
+		{ResponseData{Status: http.StatusInternalServerError, Content: fmt.Errorf("failed: %w", safeError{})}, "<h1>500 Internal Server Error</h1><p>Try again later</p>"},
+		{ResponseData{Status: http.StatusOK}, "<p></p>"},
+		{ResponseData{Status: http.StatusNotFound, Content: "content"}, "<p>content</p>"},
+	}
+
+	for _, test := range tests {
+		body, err := io.ReadAll(f.FormatBody(test.responseData))
+
+		assert.NoError(t, err, "failed to read body reader")
+		assert.Equal(t, test.expected, string(body))
+	}
+}
+
+func TestHTMLTemplateFormatterFormatBodyWithoutErrorTemplates(t *testing.T) {
+
+	template, err := template.New("test").Parse(`<p>{{.}}</p>`)
+
+	assert.NoError(t, err, "failed to parse template")
+
+	f := HTMLTemplateFormatter{
+		Template: template,
+	}
+
+	body, err := io.ReadAll(f.FormatBody(ResponseData{Status: http.StatusNotFound}))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "<p></p>", string(body))
+}
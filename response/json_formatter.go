@@ -9,16 +9,26 @@ import (
 )
 
 // JSONFormatter is a ResponseFormatter that formats responses as JSON.
-type JSONFormatter struct{}
+type JSONFormatter struct {
+	// FieldsParam is the name of a query parameter, e.g. "fields", listing the
+	// fields to include in the response as parsed by ParseFieldSelection. If
+	// the parameter selects fields on the request the response is bound to,
+	// the content is pruned to the selected fields before it is written. Field
+	// selection is disabled if FieldsParam is empty.
+	FieldsParam string
+}
 
 // FormatBody formats the response body as JSON. If the response body is nil,
 // it will be set to a map with a single key "message" and the value of
 // http.StatusText(responseData.Status). If the response body is an error,
 // the response body will be set to a map with a single key "message" and the
 // value of the error message. If the response body is an io.Reader, it will be
-// returned as is. Otherwise, the response body will be marshaled to JSON.
+// returned as is. Otherwise, the response body will be marshaled to JSON and
+// pruned to the selected fields, if any.
 func (f JSONFormatter) FormatBody(responseData ResponseData) io.Reader {
 
+	var selection FieldSelection
+
 	if responseData.Content == nil {
 		responseData.Content = map[string]string{
 			"message": http.StatusText(responseData.Status),
@@ -29,6 +39,8 @@ func (f JSONFormatter) FormatBody(responseData ResponseData) io.Reader {
 		responseData.Content = map[string]string{
 			"message": err.Error(),
 		}
+	} else {
+		selection = f.fields(responseData)
 	}
 
 	jsonBytes, err := json.Marshal(responseData.Content)
@@ -36,6 +48,13 @@ func (f JSONFormatter) FormatBody(responseData ResponseData) io.Reader {
 		panic(fmt.Errorf("failed to marshal JSON data: %w", err))
 	}
 
+	if selection != nil {
+		jsonBytes, err = selection.Apply(jsonBytes)
+		if err != nil {
+			panic(fmt.Errorf("failed to select JSON fields: %w", err))
+		}
+	}
+
 	return bytes.NewReader(jsonBytes)
 }
 
@@ -54,3 +73,23 @@ func (f JSONFormatter) FormatStatus(responseData ResponseData) int {
 	}
 	return responseData.Status
 }
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f JSONFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
+
+// fields returns the field selection of the request, or nil if there is none
+// or it is empty.
+func (f JSONFormatter) fields(responseData ResponseData) FieldSelection {
+	if f.FieldsParam == "" || responseData.Request == nil {
+		return nil
+	}
+
+	selection := ParseFieldSelection(responseData.Request.URL.Query().Get(f.FieldsParam))
+	if len(selection) == 0 {
+		return nil
+	}
+	return selection
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -141,3 +142,48 @@ func TestJSONFormatterFormatStatusZeroValue(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, status)
 }
+
+func TestJSONFormatterFormatBodyFieldSelection(t *testing.T) {
+
+	type author struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+
+	type article struct {
+		ID     int    `json:"id"`
+		Title  string `json:"title"`
+		Author author `json:"author"`
+	}
+
+	content := []article{{ID: 1, Title: "title", Author: author{Name: "name", Email: "email"}}}
+
+	f := JSONFormatter{FieldsParam: "fields"}
+
+	tests := map[string]string{
+		"http://example.org/?fields=id,author.name": `[{"id":1,"author":{"name":"name"}}]`,
+		"http://example.org/":                       `[{"id":1,"title":"title","author":{"name":"name","email":"email"}}]`,
+		"http://example.org/?fields=":               `[{"id":1,"title":"title","author":{"name":"name","email":"email"}}]`,
+		"http://example.org/?fields=,":              `[{"id":1,"title":"title","author":{"name":"name","email":"email"}}]`,
+	}
+
+	for target, expected := range tests {
+		responseData := ResponseData{
+			Content: content,
+			Request: httptest.NewRequest(http.MethodGet, target, nil),
+		}
+
+		body, err := io.ReadAll(f.FormatBody(responseData))
+
+		assert.NoError(t, err, "failed to read body reader")
+		assert.Equal(t, expected, string(body))
+	}
+
+	body, err := io.ReadAll(JSONFormatter{}.FormatBody(ResponseData{
+		Content: content,
+		Request: httptest.NewRequest(http.MethodGet, "http://example.org/?fields=id", nil),
+	}))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, `[{"id":1,"title":"title","author":{"name":"name","email":"email"}}]`, string(body))
+}
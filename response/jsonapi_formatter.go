@@ -0,0 +1,412 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONAPIFormatter is a ResponseFormatter that formats responses as JSON:API
+// documents (https://jsonapi.org).
+//
+// Resources are structs annotated with "jsonapi" struct tags:
+//
+//	type Article struct {
+//		ID     string  `jsonapi:"primary,articles"`
+//		Title  string  `jsonapi:"attr,title"`
+//		Author *Person `jsonapi:"relation,author"`
+//	}
+//
+// The primary tag marks the resource identifier and names the resource type,
+// attr tags name attributes and relation tags name relationships. Related
+// resources are added to the "included" member of the document. Resources
+// implementing JSONAPILinker get a "links" member.
+//
+// Sparse fieldsets requested using "fields[type]" query parameters on the
+// request the response is bound to are applied to the attributes and
+// relationships of the resources.
+type JSONAPIFormatter struct{}
+
+// JSONAPILinker is implemented by resources that provide JSON:API links.
+type JSONAPILinker interface {
+	JSONAPILinks() map[string]any
+}
+
+// JSONAPIError is a JSON:API error object. Errors implementing
+// JSONAPIErrorer are formatted using the error objects they return.
+type JSONAPIError struct {
+	ID     string            `json:"id,omitempty"`
+	Status string            `json:"status,omitempty"`
+	Code   string            `json:"code,omitempty"`
+	Title  string            `json:"title,omitempty"`
+	Detail string            `json:"detail,omitempty"`
+	Source map[string]string `json:"source,omitempty"`
+}
+
+// JSONAPIErrorer is implemented by errors that provide their own JSON:API
+// error objects.
+type JSONAPIErrorer interface {
+	JSONAPIErrors() []JSONAPIError
+}
+
+type jsonapiIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type jsonapiRelationship struct {
+	Data any `json:"data"`
+}
+
+type jsonapiResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    map[string]any                 `json:"attributes,omitempty"`
+	Relationships map[string]jsonapiRelationship `json:"relationships,omitempty"`
+	Links         map[string]any                 `json:"links,omitempty"`
+}
+
+type jsonapiDocument struct {
+	Data     any               `json:"data"`
+	Included []jsonapiResource `json:"included,omitempty"`
+}
+
+// FormatBody formats the response body as a JSON:API document. If the response
+// body is nil and the status is 400 or above, or the response body is an error,
+// it will be formatted as a document with an "errors" member. A nil response
+// body with any other status results in a document with null data. If the
+// response body is an io.Reader, it will be returned as is. Will panic if the
+// content is not a resource or a slice of resources.
+func (f JSONAPIFormatter) FormatBody(responseData ResponseData) io.Reader {
+
+	var document any
+
+	status := f.FormatStatus(responseData)
+
+	if responseData.Content == nil {
+		if status >= 400 {
+			document = jsonapiErrors(status, http.StatusText(status))
+		} else {
+			document = jsonapiDocument{}
+		}
+	} else if reader, ok := responseData.Content.(io.Reader); ok {
+		return reader
+	} else if errorer, ok := responseData.Content.(JSONAPIErrorer); ok {
+		document = map[string]any{"errors": errorer.JSONAPIErrors()}
+	} else if err, ok := responseData.Content.(error); ok {
+		document = jsonapiErrors(status, err.Error())
+	} else {
+		var err error
+		document, err = newJSONAPIDocument(responseData.Content, jsonapiFields(responseData.Request))
+		if err != nil {
+			panic(fmt.Errorf("failed to marshal JSON:API data: %w", err))
+		}
+	}
+
+	jsonBytes, err := json.Marshal(document)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal JSON:API data: %w", err))
+	}
+
+	return bytes.NewReader(jsonBytes)
+}
+
+// FormatHeader formats the response header by setting the Content-Type to
+// "application/vnd.api+json".
+func (f JSONAPIFormatter) FormatHeader(responseData ResponseData) http.Header {
+	responseData.Header.Set("Content-Type", "application/vnd.api+json")
+	return responseData.Header
+}
+
+// FormatStatus formats the response status. If the status is 0, it will be
+// set to http.StatusOK.
+func (f JSONAPIFormatter) FormatStatus(responseData ResponseData) int {
+	if responseData.Status == 0 {
+		return http.StatusOK
+	}
+	return responseData.Status
+}
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f JSONAPIFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
+
+// jsonapiErrors returns a document with a single error object.
+func jsonapiErrors(status int, detail string) map[string]any {
+	return map[string]any{
+		"errors": []JSONAPIError{{
+			Status: strconv.Itoa(status),
+			Title:  http.StatusText(status),
+			Detail: detail,
+		}},
+	}
+}
+
+// jsonapiFields returns the sparse fieldsets requested by r, keyed by resource
+// type.
+func jsonapiFields(r *http.Request) map[string]map[string]bool {
+	if r == nil {
+		return nil
+	}
+
+	var fields map[string]map[string]bool
+	for key, values := range r.URL.Query() {
+		if !strings.HasPrefix(key, "fields[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+
+		typ := key[len("fields[") : len(key)-1]
+		names := map[string]bool{}
+		for _, value := range values {
+			for _, name := range strings.Split(value, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					names[name] = true
+				}
+			}
+		}
+
+		if fields == nil {
+			fields = map[string]map[string]bool{}
+		}
+		fields[typ] = names
+	}
+	return fields
+}
+
+// jsonapiBuilder builds a JSON:API document, collecting included resources.
+type jsonapiBuilder struct {
+	fields   map[string]map[string]bool
+	included []jsonapiResource
+	seen     map[jsonapiIdentifier]bool
+}
+
+// newJSONAPIDocument returns the JSON:API document for a resource or a slice of
+// resources, applying the given sparse fieldsets.
+func newJSONAPIDocument(content any, fields map[string]map[string]bool) (jsonapiDocument, error) {
+	b := &jsonapiBuilder{
+		fields: fields,
+		seen:   map[jsonapiIdentifier]bool{},
+	}
+
+	v := jsonapiIndirect(reflect.ValueOf(content))
+	if !v.IsValid() {
+		return jsonapiDocument{}, nil
+	}
+
+	var document jsonapiDocument
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		data := make([]jsonapiResource, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			resource, err := b.resource(v.Index(i))
+			if err != nil {
+				return document, err
+			}
+			if resource != nil {
+				data = append(data, *resource)
+			}
+		}
+		document.Data = data
+	} else {
+		resource, err := b.resource(v)
+		if err != nil {
+			return document, err
+		}
+		document.Data = resource
+	}
+
+	// Primary data must not be duplicated in included.
+	included := b.included[:0]
+	for _, resource := range b.included {
+		if !b.isPrimary(resource, document.Data) {
+			included = append(included, resource)
+		}
+	}
+	document.Included = included
+
+	return document, nil
+}
+
+// isPrimary reports whether the resource is part of the primary data.
+func (b *jsonapiBuilder) isPrimary(resource jsonapiResource, data any) bool {
+	switch d := data.(type) {
+	case *jsonapiResource:
+		return d.Type == resource.Type && d.ID == resource.ID
+	case []jsonapiResource:
+		for _, r := range d {
+			if r.Type == resource.Type && r.ID == resource.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resource returns the resource object for v. Returns nil for nil pointers.
+func (b *jsonapiBuilder) resource(v reflect.Value) (*jsonapiResource, error) {
+	v = jsonapiIndirect(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	identifier, err := jsonapiIdentify(v)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &jsonapiResource{Type: identifier.Type, ID: identifier.ID}
+	selected, sparse := b.fields[identifier.Type]
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		tag, ok := sf.Tag.Lookup("jsonapi")
+		if !ok || !sf.IsExported() {
+			continue
+		}
+
+		kind, args, _ := strings.Cut(tag, ",")
+		name, options, _ := strings.Cut(args, ",")
+		field := v.Field(i)
+
+		if sparse && kind != "primary" && !selected[name] {
+			continue
+		}
+
+		switch kind {
+		case "primary":
+		case "attr":
+			if options == "omitempty" && field.IsZero() {
+				continue
+			}
+			if resource.Attributes == nil {
+				resource.Attributes = map[string]any{}
+			}
+			resource.Attributes[name] = field.Interface()
+		case "relation":
+			relationship, err := b.relationship(field)
+			if err != nil {
+				return nil, fmt.Errorf("relation %s: %w", name, err)
+			}
+			if resource.Relationships == nil {
+				resource.Relationships = map[string]jsonapiRelationship{}
+			}
+			resource.Relationships[name] = relationship
+		default:
+			return nil, fmt.Errorf("invalid jsonapi tag %q on %s.%s", tag, v.Type(), sf.Name)
+		}
+	}
+
+	if linker, ok := v.Interface().(JSONAPILinker); ok {
+		resource.Links = linker.JSONAPILinks()
+	} else if v.CanAddr() {
+		if linker, ok := v.Addr().Interface().(JSONAPILinker); ok {
+			resource.Links = linker.JSONAPILinks()
+		}
+	}
+
+	return resource, nil
+}
+
+// relationship returns the relationship object for a relation field and adds
+// the related resources to the included resources.
+func (b *jsonapiBuilder) relationship(v reflect.Value) (jsonapiRelationship, error) {
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		identifiers := make([]jsonapiIdentifier, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			identifier, err := b.include(v.Index(i))
+			if err != nil {
+				return jsonapiRelationship{}, err
+			}
+			if identifier != nil {
+				identifiers = append(identifiers, *identifier)
+			}
+		}
+		return jsonapiRelationship{Data: identifiers}, nil
+	}
+
+	identifier, err := b.include(v)
+	if err != nil || identifier == nil {
+		return jsonapiRelationship{}, err
+	}
+	return jsonapiRelationship{Data: identifier}, nil
+}
+
+// include adds the related resource to the included resources and returns its
+// identifier. Resources are included only once, which also stops the recursion
+// for cyclic relationships.
+func (b *jsonapiBuilder) include(v reflect.Value) (*jsonapiIdentifier, error) {
+	v = jsonapiIndirect(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	identifier, err := jsonapiIdentify(v)
+	if err != nil {
+		return nil, err
+	}
+	if b.seen[identifier] {
+		return &identifier, nil
+	}
+	b.seen[identifier] = true
+
+	i := len(b.included)
+	b.included = append(b.included, jsonapiResource{})
+
+	resource, err := b.resource(v)
+	if err != nil {
+		return nil, err
+	}
+	b.included[i] = *resource
+
+	return &identifier, nil
+}
+
+// jsonapiIndirect dereferences pointers and interfaces. Returns the zero Value
+// if a nil pointer or interface is encountered.
+func jsonapiIndirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// jsonapiIdentify returns the identifier of the resource v, taken from the
+// field tagged as primary.
+func jsonapiIdentify(v reflect.Value) (jsonapiIdentifier, error) {
+	if v.Kind() != reflect.Struct {
+		return jsonapiIdentifier{}, fmt.Errorf("%s is not a resource", v.Type())
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		kind, args, _ := strings.Cut(v.Type().Field(i).Tag.Get("jsonapi"), ",")
+		if kind == "primary" {
+			name, _, _ := strings.Cut(args, ",")
+			return jsonapiIdentifier{Type: name, ID: jsonapiID(v.Field(i))}, nil
+		}
+	}
+
+	return jsonapiIdentifier{}, fmt.Errorf("%s has no primary field", v.Type())
+}
+
+// jsonapiID formats a primary field value as a resource identifier.
+func jsonapiID(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,224 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonapiPerson struct {
+	ID       int               `jsonapi:"primary,people"`
+	Name     string            `jsonapi:"attr,name"`
+	Articles []*jsonapiArticle `jsonapi:"relation,articles"`
+}
+
+type jsonapiArticle struct {
+	ID       string         `jsonapi:"primary,articles"`
+	Title    string         `jsonapi:"attr,title"`
+	Subtitle string         `jsonapi:"attr,subtitle,omitempty"`
+	Author   *jsonapiPerson `jsonapi:"relation,author"`
+	Internal string
+}
+
+func (a jsonapiArticle) JSONAPILinks() map[string]any {
+	return map[string]any{"self": "/articles/" + a.ID}
+}
+
+type jsonapiValidationError struct{}
+
+func (e jsonapiValidationError) Error() string {
+	return "validation failed"
+}
+
+func (e jsonapiValidationError) JSONAPIErrors() []JSONAPIError {
+	return []JSONAPIError{
+		{Status: "422", Detail: "is required", Source: map[string]string{"pointer": "/data/attributes/title"}},
+	}
+}
+
+func TestJSONAPIFormatterFormatBody(t *testing.T) {
+
+	author := &jsonapiPerson{ID: 9, Name: "Dan"}
+	article := &jsonapiArticle{ID: "1", Title: "JSON:API", Author: author, Internal: "x"}
+	author.Articles = []*jsonapiArticle{article}
+
+	responseData := ResponseData{
+		Content: article,
+	}
+
+	f := JSONAPIFormatter{}
+
+	body, err := io.ReadAll(f.FormatBody(responseData))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{
+		"data": {
+			"type": "articles",
+			"id": "1",
+			"attributes": {"title": "JSON:API"},
+			"relationships": {"author": {"data": {"type": "people", "id": "9"}}},
+			"links": {"self": "/articles/1"}
+		},
+		"included": [{
+			"type": "people",
+			"id": "9",
+			"attributes": {"name": "Dan"},
+			"relationships": {"articles": {"data": [{"type": "articles", "id": "1"}]}}
+		}]
+	}`, string(body))
+}
+
+func TestJSONAPIFormatterFormatBodyCollection(t *testing.T) {
+
+	author := &jsonapiPerson{ID: 9, Name: "Dan"}
+
+	responseData := ResponseData{
+		Content: []jsonapiArticle{
+			{ID: "1", Title: "One", Author: author},
+			{ID: "2", Title: "Two", Subtitle: "Sub", Author: author},
+			{ID: "3", Title: "Three"},
+		},
+	}
+
+	f := JSONAPIFormatter{}
+
+	body, err := io.ReadAll(f.FormatBody(responseData))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{
+		"data": [
+			{"type": "articles", "id": "1", "attributes": {"title": "One"}, "relationships": {"author": {"data": {"type": "people", "id": "9"}}}, "links": {"self": "/articles/1"}},
+			{"type": "articles", "id": "2", "attributes": {"title": "Two", "subtitle": "Sub"}, "relationships": {"author": {"data": {"type": "people", "id": "9"}}}, "links": {"self": "/articles/2"}},
+			{"type": "articles", "id": "3", "attributes": {"title": "Three"}, "relationships": {"author": {"data": null}}, "links": {"self": "/articles/3"}}
+		],
+		"included": [
+			{"type": "people", "id": "9", "attributes": {"name": "Dan"}, "relationships": {"articles": {"data": []}}}
+		]
+	}`, string(body))
+}
+
+func TestJSONAPIFormatterFormatStream(t *testing.T) {
+
+	body := []byte("body")
+
+	responseData := ResponseData{
+		Content: bytes.NewReader(body),
+	}
+
+	f := JSONAPIFormatter{}
+
+	responseBody, err := io.ReadAll(f.FormatBody(responseData))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, responseBody, body)
+}
+
+func TestJSONAPIFormatterFormatBodyNilContent(t *testing.T) {
+
+	f := JSONAPIFormatter{}
+
+	body, err := io.ReadAll(f.FormatBody(ResponseData{Status: http.StatusNotFound}))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{"errors":[{"status":"404","title":"Not Found","detail":"Not Found"}]}`, string(body))
+
+	body, err = io.ReadAll(f.FormatBody(ResponseData{}))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{"data":null}`, string(body))
+}
+
+func TestJSONAPIFormatterFormatBodyErrorContent(t *testing.T) {
+
+	f := JSONAPIFormatter{}
+
+	body, err := io.ReadAll(f.FormatBody(ResponseData{
+		Content: errors.New("some error"),
+		Status:  http.StatusBadRequest,
+	}))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{"errors":[{"status":"400","title":"Bad Request","detail":"some error"}]}`, string(body))
+
+	body, err = io.ReadAll(f.FormatBody(ResponseData{
+		Content: jsonapiValidationError{},
+		Status:  http.StatusUnprocessableEntity,
+	}))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{"errors":[{"status":"422","detail":"is required","source":{"pointer":"/data/attributes/title"}}]}`, string(body))
+}
+
+func TestJSONAPIFormatterFormatBodyBadContent(t *testing.T) {
+
+	f := JSONAPIFormatter{}
+
+	for _, content := range []any{
+		map[string]any{},
+		struct{ Name string }{},
+		struct {
+			ID string `jsonapi:"primary,things"`
+			X  string `jsonapi:"bogus,x"`
+		}{},
+	} {
+		assert.Panics(t, func() {
+			f.FormatBody(ResponseData{Content: content})
+		})
+	}
+}
+
+func TestJSONAPIFormatterFormatHeader(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	responseData.Header.Set("key", "value")
+
+	f := JSONAPIFormatter{}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "application/vnd.api+json", header.Get("Content-Type"))
+	assert.Equal(t, "value", header.Get("key"))
+	assert.Equal(t, 2, len(header))
+}
+
+func TestJSONAPIFormatterFormatStatusZeroValue(t *testing.T) {
+
+	f := JSONAPIFormatter{}
+
+	assert.Equal(t, http.StatusOK, f.FormatStatus(ResponseData{}))
+}
+
+func TestJSONAPIFormatterFormatBodySparseFieldsets(t *testing.T) {
+
+	author := &jsonapiPerson{ID: 9, Name: "Dan"}
+	article := &jsonapiArticle{ID: "1", Title: "JSON:API", Subtitle: "Sub", Author: author}
+
+	responseData := ResponseData{
+		Content: article,
+		Request: httptest.NewRequest(http.MethodGet, "http://example.org/?fields[articles]=title,author&fields[people]=", nil),
+	}
+
+	f := JSONAPIFormatter{}
+
+	body, err := io.ReadAll(f.FormatBody(responseData))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.JSONEq(t, `{
+		"data": {
+			"type": "articles",
+			"id": "1",
+			"attributes": {"title": "JSON:API"},
+			"relationships": {"author": {"data": {"type": "people", "id": "9"}}},
+			"links": {"self": "/articles/1"}
+		},
+		"included": [{"type": "people", "id": "9"}]
+	}`, string(body))
+}
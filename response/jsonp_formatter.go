@@ -0,0 +1,126 @@
+package response
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidCallback is the response content used by JSONPFormatter when the
+// callback name of the request is not a safe JavaScript identifier.
+var ErrInvalidCallback = errors.New("invalid callback")
+
+// JSONPFormatter is a ResponseFormatter that formats responses as JSONP for
+// legacy clients. The JSON formatted content is wrapped in a call to the
+// function named by the callback query parameter of the request the response
+// is bound to. If there is no callback parameter, the response is formatted as
+// plain JSON.
+//
+// Only dotted JavaScript identifiers such as "cb" or "jQuery.handlers.cb" are
+// accepted as callback names. Requests with other callback names get a 400 Bad
+// Request JSON response with ErrInvalidCallback as content.
+type JSONPFormatter struct {
+	// CallbackParam is the name of the query parameter holding the callback
+	// name. Defaults to "callback".
+	CallbackParam string
+}
+
+// maxCallbackLength is the maximum accepted length of a callback name.
+const maxCallbackLength = 128
+
+var callbackPattern = regexp.MustCompile(`^[A-Za-z_$][0-9A-Za-z_$]*(?:\.[A-Za-z_$][0-9A-Za-z_$]*)*$`)
+
+var reservedWords = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true,
+	"do": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "for": true, "function": true, "if": true,
+	"implements": true, "import": true, "in": true, "instanceof": true,
+	"interface": true, "let": true, "new": true, "null": true, "package": true,
+	"private": true, "protected": true, "public": true, "return": true,
+	"static": true, "super": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true, "yield": true,
+}
+
+// FormatBody formats the response body as JSON using JSONFormatter and wraps it
+// in a call to the callback function. The body is prefixed with an empty
+// comment to protect against content sniffing attacks.
+func (f JSONPFormatter) FormatBody(responseData ResponseData) io.Reader {
+	callback, ok := f.callback(responseData)
+	if !ok {
+		responseData.Content = ErrInvalidCallback
+	}
+
+	body := JSONFormatter{}.FormatBody(responseData)
+	if callback == "" {
+		return body
+	}
+
+	return io.MultiReader(
+		strings.NewReader("/**/"+callback+"("),
+		body,
+		strings.NewReader(");"),
+	)
+}
+
+// FormatHeader formats the response header by setting the Content-Type to
+// "application/javascript" and X-Content-Type-Options to "nosniff", or to
+// "application/json" if there is no valid callback.
+func (f JSONPFormatter) FormatHeader(responseData ResponseData) http.Header {
+	if callback, _ := f.callback(responseData); callback == "" {
+		return JSONFormatter{}.FormatHeader(responseData)
+	}
+
+	responseData.Header.Set("Content-Type", "application/javascript")
+	responseData.Header.Set("X-Content-Type-Options", "nosniff")
+	return responseData.Header
+}
+
+// FormatStatus formats the response status. If the callback is invalid, it
+// will be set to http.StatusBadRequest. If the status is 0, it will be set to
+// http.StatusOK.
+func (f JSONPFormatter) FormatStatus(responseData ResponseData) int {
+	if _, ok := f.callback(responseData); !ok {
+		return http.StatusBadRequest
+	}
+	return JSONFormatter{}.FormatStatus(responseData)
+}
+
+// callback returns the callback name of the request. Returns false if the
+// request has a callback which is not a safe identifier.
+func (f JSONPFormatter) callback(responseData ResponseData) (string, bool) {
+	if responseData.Request == nil {
+		return "", true
+	}
+
+	param := f.CallbackParam
+	if param == "" {
+		param = "callback"
+	}
+
+	callback := responseData.Request.URL.Query().Get(param)
+	if callback == "" {
+		return "", true
+	}
+
+	if !validCallback(callback) {
+		return "", false
+	}
+	return callback, true
+}
+
+// validCallback reports whether name is a safe JavaScript callback name.
+func validCallback(name string) bool {
+	if len(name) > maxCallbackLength || !callbackPattern.MatchString(name) {
+		return false
+	}
+	for _, part := range strings.Split(name, ".") {
+		if reservedWords[part] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package response
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func jsonpResponseData(query string) ResponseData {
+	return ResponseData{
+		Content: map[string]any{"key": "value"},
+		Header:  http.Header{},
+		Request: httptest.NewRequest(http.MethodGet, "http://example.org/?"+query, nil),
+	}
+}
+
+func TestJSONPFormatterFormatBody(t *testing.T) {
+
+	f := JSONPFormatter{}
+
+	responseData := jsonpResponseData("callback=jQuery.cb_1")
+
+	body, err := io.ReadAll(f.FormatBody(responseData))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, `/**/jQuery.cb_1({"key":"value"});`, string(body))
+	assert.Equal(t, http.StatusOK, f.FormatStatus(responseData))
+
+	header := f.FormatHeader(responseData)
+	assert.Equal(t, "application/javascript", header.Get("Content-Type"))
+	assert.Equal(t, "nosniff", header.Get("X-Content-Type-Options"))
+}
+
+func TestJSONPFormatterCallbackParam(t *testing.T) {
+
+	f := JSONPFormatter{CallbackParam: "jsonp"}
+
+	body, err := io.ReadAll(f.FormatBody(jsonpResponseData("jsonp=$cb&callback=other")))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, `/**/$cb({"key":"value"});`, string(body))
+}
+
+func TestJSONPFormatterWithoutCallback(t *testing.T) {
+
+	f := JSONPFormatter{}
+
+	for _, responseData := range []ResponseData{
+		jsonpResponseData(""),
+		{Content: map[string]any{"key": "value"}, Header: http.Header{}},
+	} {
+		body, err := io.ReadAll(f.FormatBody(responseData))
+
+		assert.NoError(t, err, "failed to read body reader")
+		assert.Equal(t, `{"key":"value"}`, string(body))
+		assert.Equal(t, "application/json", f.FormatHeader(responseData).Get("Content-Type"))
+	}
+}
+
+func TestJSONPFormatterInvalidCallback(t *testing.T) {
+
+	f := JSONPFormatter{}
+
+	for _, callback := range []string{
+		"alert(1)//",
+		"cb;alert(1)",
+		"1cb",
+		"cb.",
+		"a..b",
+		"window.function",
+		"<script>",
+		strings.Repeat("a", maxCallbackLength+1),
+	} {
+		responseData := jsonpResponseData("callback=" + url.QueryEscape(callback))
+
+		body, err := io.ReadAll(f.FormatBody(responseData))
+
+		assert.NoError(t, err, "failed to read body reader")
+		assert.Equal(t, `{"message":"invalid callback"}`, string(body), callback)
+		assert.Equal(t, http.StatusBadRequest, f.FormatStatus(responseData), callback)
+		assert.Equal(t, "application/json", f.FormatHeader(responseData).Get("Content-Type"), callback)
+	}
+}
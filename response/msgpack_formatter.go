@@ -0,0 +1,65 @@
+package response
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MessagePackFormatter is a ResponseFormatter that formats responses as
+// MessagePack.
+type MessagePackFormatter struct{}
+
+// FormatBody formats the response body as MessagePack. If the response body is
+// nil, it will be set to a map with a single key "message" and the value of
+// http.StatusText(responseData.Status). If the response body is an error,
+// the response body will be set to a map with a single key "message" and the
+// value of the error message. If the response body is an io.Reader, it will be
+// returned as is. Otherwise, the response body will be marshaled to
+// MessagePack.
+func (f MessagePackFormatter) FormatBody(responseData ResponseData) io.Reader {
+
+	if responseData.Content == nil {
+		responseData.Content = map[string]string{
+			"message": http.StatusText(responseData.Status),
+		}
+	} else if reader, ok := responseData.Content.(io.Reader); ok {
+		return reader
+	} else if err, ok := responseData.Content.(error); ok {
+		responseData.Content = map[string]string{
+			"message": err.Error(),
+		}
+	}
+
+	msgpackBytes, err := msgpack.Marshal(responseData.Content)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal MessagePack data: %w", err))
+	}
+
+	return bytes.NewReader(msgpackBytes)
+}
+
+// FormatHeader formats the response header by setting the Content-Type to
+// "application/msgpack".
+func (f MessagePackFormatter) FormatHeader(responseData ResponseData) http.Header {
+	responseData.Header.Set("Content-Type", "application/msgpack")
+	return responseData.Header
+}
+
+// FormatStatus formats the response status. If the status is 0, it will be
+// set to http.StatusOK.
+func (f MessagePackFormatter) FormatStatus(responseData ResponseData) int {
+	if responseData.Status == 0 {
+		return http.StatusOK
+	}
+	return responseData.Status
+}
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f MessagePackFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
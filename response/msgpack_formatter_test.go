@@ -0,0 +1,134 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMessagePackFormatterFormatBody(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: map[string]any{
+			"key": "value",
+		},
+	}
+
+	f := MessagePackFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+
+	var content map[string]any
+	assert.NoError(t, msgpack.Unmarshal(body, &content))
+	assert.Equal(t, map[string]any{"key": "value"}, content)
+}
+
+func TestMessagePackFormatterFormatStream(t *testing.T) {
+
+	body := []byte("body")
+
+	responseData := ResponseData{
+		Content: bytes.NewReader(body),
+	}
+
+	f := MessagePackFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	responseBody, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, responseBody, body)
+}
+
+func TestMessagePackFormatterFormatBodyNilContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: nil,
+		Status:  http.StatusBadRequest,
+	}
+
+	f := MessagePackFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+
+	var content map[string]string
+	assert.NoError(t, msgpack.Unmarshal(body, &content))
+	assert.Equal(t, map[string]string{"message": http.StatusText(http.StatusBadRequest)}, content)
+}
+
+func TestMessagePackFormatterFormatBodyErrorContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: errors.New("some error"),
+		Status:  http.StatusBadRequest,
+	}
+
+	f := MessagePackFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+
+	var content map[string]string
+	assert.NoError(t, msgpack.Unmarshal(body, &content))
+	assert.Equal(t, map[string]string{"message": "some error"}, content)
+}
+
+func TestMessagePackFormatterFormatBodyBadContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: func() {},
+	}
+
+	f := MessagePackFormatter{}
+
+	testPanic := func() {
+		f.FormatBody(responseData)
+	}
+
+	assert.Panics(t, testPanic, "did not panic on bad MessagePack marshalling input type")
+}
+
+func TestMessagePackFormatterFormatHeader(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	responseData.Header.Set("key", "value")
+
+	f := MessagePackFormatter{}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "application/msgpack", header.Get("Content-Type"))
+	assert.Equal(t, "value", header.Get("key"))
+	assert.Equal(t, 2, len(header))
+}
+
+func TestMessagePackFormatterFormatStatusZeroValue(t *testing.T) {
+
+	responseData := ResponseData{}
+
+	f := MessagePackFormatter{}
+
+	status := f.FormatStatus(responseData)
+
+	assert.Equal(t, http.StatusOK, status)
+}
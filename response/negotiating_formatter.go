@@ -0,0 +1,145 @@
+package response
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MediaTypeFormatter associates a media type with the Formatter producing it.
+type MediaTypeFormatter struct {
+	// MediaType is the media type offered, e.g. "application/json".
+	MediaType string
+	// Formatter is the formatter used when the media type is selected.
+	Formatter Formatter
+}
+
+// NegotiatingFormatter is a ResponseFormatter that selects one of several
+// formatters based on the Accept header of the request the response is bound
+// to. The offer with the highest quality value wins, ties are resolved in
+// favour of the earliest offer. If the response is not bound to a request, the
+// request has no Accept header or none of the offers are acceptable, the first
+// offer is used.
+type NegotiatingFormatter struct {
+	Offers []MediaTypeFormatter
+}
+
+// FormatBody formats the response body using the negotiated formatter.
+func (f NegotiatingFormatter) FormatBody(responseData ResponseData) io.Reader {
+	return f.negotiate(responseData).FormatBody(responseData)
+}
+
+// FormatHeader formats the response header using the negotiated formatter and
+// adds Accept to the Vary header.
+func (f NegotiatingFormatter) FormatHeader(responseData ResponseData) http.Header {
+	header := f.negotiate(responseData).FormatHeader(responseData)
+	for _, v := range header.Values("Vary") {
+		if strings.EqualFold(v, "Accept") {
+			return header
+		}
+	}
+	header.Add("Vary", "Accept")
+	return header
+}
+
+// FormatStatus formats the response status using the negotiated formatter.
+func (f NegotiatingFormatter) FormatStatus(responseData ResponseData) int {
+	return f.negotiate(responseData).FormatStatus(responseData)
+}
+
+// ContentLength returns the content length reported by the negotiated
+// formatter, if it implements ContentLengthFormatter.
+func (f NegotiatingFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	if formatter, ok := f.negotiate(responseData).(ContentLengthFormatter); ok {
+		return formatter.ContentLength(responseData)
+	}
+	return 0, false
+}
+
+// negotiate returns the formatter best matching the Accept header of the
+// request. Will panic if there are no offers.
+func (f NegotiatingFormatter) negotiate(responseData ResponseData) Formatter {
+	if len(f.Offers) == 0 {
+		panic("negotiating formatter has no offers")
+	}
+
+	if responseData.Request == nil {
+		return f.Offers[0].Formatter
+	}
+
+	accept := parseAccept(responseData.Request.Header.Values("Accept"))
+	if len(accept) == 0 {
+		return f.Offers[0].Formatter
+	}
+
+	best, bestQ := 0, 0.0
+	for i, offer := range f.Offers {
+		if q := accept.quality(offer.MediaType); q > bestQ {
+			best, bestQ = i, q
+		}
+	}
+	return f.Offers[best].Formatter
+}
+
+type mediaRange struct {
+	typ     string
+	subtype string
+	q       float64
+}
+
+type acceptHeader []mediaRange
+
+// parseAccept parses the values of an Accept header into media ranges.
+// Malformed ranges are ignored.
+func parseAccept(values []string) acceptHeader {
+	var accept acceptHeader
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			mediaType, params, _ := strings.Cut(part, ";")
+			typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(mediaType)), "/")
+			if !ok || typ == "" || subtype == "" {
+				continue
+			}
+
+			r := mediaRange{typ: typ, subtype: subtype, q: 1}
+			for _, param := range strings.Split(params, ";") {
+				key, value, _ := strings.Cut(param, "=")
+				if strings.TrimSpace(strings.ToLower(key)) != "q" {
+					continue
+				}
+				if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					r.q = q
+				}
+			}
+			accept = append(accept, r)
+		}
+	}
+	return accept
+}
+
+// quality returns the quality value of the most specific media range matching
+// the given media type.
+func (a acceptHeader) quality(mediaType string) float64 {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	typ, subtype, _ := strings.Cut(strings.ToLower(strings.TrimSpace(mediaType)), "/")
+
+	q, specificity := 0.0, -1
+	for _, r := range a {
+		var s int
+		switch {
+		case r.typ == typ && r.subtype == subtype:
+			s = 2
+		case r.typ == typ && r.subtype == "*":
+			s = 1
+		case r.typ == "*" && r.subtype == "*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
@@ -0,0 +1,86 @@
+package response
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var negotiatingFormatter = NegotiatingFormatter{
+	Offers: []MediaTypeFormatter{
+		{MediaType: "application/json", Formatter: JSONFormatter{}},
+		{MediaType: "application/yaml", Formatter: YAMLFormatter{}},
+		{MediaType: "application/xml", Formatter: XMLFormatter{}},
+	},
+}
+
+func negotiatingResponseData(accept string) ResponseData {
+	request := httptest.NewRequest(http.MethodGet, "http://example.org", nil)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	return ResponseData{
+		Content: map[string]string{"key": "value"},
+		Header:  http.Header{},
+		Request: request,
+	}
+}
+
+func TestNegotiatingFormatterFormatHeader(t *testing.T) {
+
+	tests := map[string]string{
+		"":                                     "application/json",
+		"application/yaml":                     "application/yaml",
+		"application/xml, application/yaml":    "application/yaml",
+		"application/xml;q=0.9, */*;q=0.1":     "application/xml",
+		"application/*;q=0.5, application/xml": "application/xml",
+		"text/html":                            "application/json",
+		"application/json;q=0, */*":            "application/yaml",
+	}
+
+	for accept, contentType := range tests {
+		header := negotiatingFormatter.FormatHeader(negotiatingResponseData(accept))
+
+		assert.Equal(t, contentType, header.Get("Content-Type"), accept)
+		assert.Equal(t, []string{"Accept"}, header.Values("Vary"), accept)
+	}
+}
+
+func TestNegotiatingFormatterFormatBody(t *testing.T) {
+
+	bodyReader := negotiatingFormatter.FormatBody(negotiatingResponseData("application/yaml"))
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "key: value\n", string(body))
+}
+
+func TestNegotiatingFormatterWithoutRequest(t *testing.T) {
+
+	responseData := negotiatingResponseData("")
+	responseData.Request = nil
+
+	header := negotiatingFormatter.FormatHeader(responseData)
+
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+}
+
+func TestNegotiatingFormatterFormatStatusZeroValue(t *testing.T) {
+
+	status := negotiatingFormatter.FormatStatus(negotiatingResponseData("application/xml"))
+
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestNegotiatingFormatterPanicsWithoutOffers(t *testing.T) {
+
+	f := NegotiatingFormatter{}
+
+	assert.Panics(t, func() {
+		f.FormatStatus(ResponseData{})
+	})
+}
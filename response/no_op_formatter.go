@@ -28,3 +28,9 @@ func (f NoOpFormatter) FormatHeader(responseData ResponseData) http.Header {
 func (f NoOpFormatter) FormatStatus(responseData ResponseData) int {
 	return responseData.Status
 }
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f NoOpFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
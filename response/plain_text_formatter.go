@@ -0,0 +1,52 @@
+package response
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// PlainTextFormatter is a ResponseFormatter that formats responses as plain
+// text.
+type PlainTextFormatter struct{}
+
+// FormatBody formats the response body as plain text. If the response body is
+// nil, it will be set to http.StatusText(responseData.Status). If the response
+// body is an error, it will be set to the error message. If the response body
+// is an io.Reader, it will be returned as is. Otherwise, the response body will
+// be formatted using fmt.Sprintf("%v").
+func (f PlainTextFormatter) FormatBody(responseData ResponseData) io.Reader {
+
+	if responseData.Content == nil {
+		return strings.NewReader(http.StatusText(responseData.Status))
+	} else if reader, ok := responseData.Content.(io.Reader); ok {
+		return reader
+	} else if err, ok := responseData.Content.(error); ok {
+		return strings.NewReader(err.Error())
+	}
+
+	return strings.NewReader(fmt.Sprintf("%v", responseData.Content))
+}
+
+// FormatHeader formats the response header by setting the Content-Type to
+// "text/plain; charset=utf-8".
+func (f PlainTextFormatter) FormatHeader(responseData ResponseData) http.Header {
+	responseData.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	return responseData.Header
+}
+
+// FormatStatus formats the response status. If the status is 0, it will be
+// set to http.StatusOK.
+func (f PlainTextFormatter) FormatStatus(responseData ResponseData) int {
+	if responseData.Status == 0 {
+		return http.StatusOK
+	}
+	return responseData.Status
+}
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f PlainTextFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
@@ -0,0 +1,120 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlainTextFormatterFormatBody(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: 42,
+	}
+
+	f := PlainTextFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "42", string(body))
+}
+
+func TestPlainTextFormatterFormatStream(t *testing.T) {
+
+	body := []byte("body")
+
+	responseData := ResponseData{
+		Content: bytes.NewReader(body),
+	}
+
+	f := PlainTextFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	responseBody, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, responseBody, body)
+}
+
+func TestPlainTextFormatterFormatBodyNilContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: nil,
+		Status:  http.StatusBadRequest,
+	}
+
+	f := PlainTextFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, http.StatusText(http.StatusBadRequest), string(body))
+}
+
+func TestPlainTextFormatterFormatBodyErrorContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: errors.New("some error"),
+		Status:  http.StatusBadRequest,
+	}
+
+	f := PlainTextFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "some error", string(body))
+}
+
+func TestPlainTextFormatterFormatHeader(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	responseData.Header.Set("key", "value")
+
+	f := PlainTextFormatter{}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "text/plain; charset=utf-8", header.Get("Content-Type"))
+	assert.Equal(t, "value", header.Get("key"))
+	assert.Equal(t, 2, len(header))
+}
+
+func TestPlainTextFormatterFormatStatus(t *testing.T) {
+
+	responseData := ResponseData{
+		Status: http.StatusBadRequest,
+	}
+
+	f := PlainTextFormatter{}
+
+	status := f.FormatStatus(responseData)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestPlainTextFormatterFormatStatusZeroValue(t *testing.T) {
+
+	responseData := ResponseData{}
+
+	f := PlainTextFormatter{}
+
+	status := f.FormatStatus(responseData)
+
+	assert.Equal(t, http.StatusOK, status)
+}
@@ -0,0 +1,146 @@
+package response
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtobufFormatter is a ResponseFormatter that formats proto.Message content
+// using the Protocol Buffers binary wire format. If the response is bound to a
+// request that prefers "application/json" over "application/x-protobuf", the
+// content is formatted using the protojson encoding instead.
+type ProtobufFormatter struct{}
+
+// FormatBody formats the response body as Protocol Buffers. If the response
+// body is nil, it will be set to a google.rpc.Status message with the code
+// matching responseData.Status and a message of
+// http.StatusText(responseData.Status). If the response body is an error, the
+// google.rpc.Status message will contain the error message, and the code will
+// be UNKNOWN if the status does not indicate an error. If the response body is
+// an io.Reader, it will be returned as is. Will panic if the content is not a
+// proto.Message or fails to marshal.
+func (f ProtobufFormatter) FormatBody(responseData ResponseData) io.Reader {
+
+	var message proto.Message
+
+	if responseData.Content == nil {
+		message = protobufStatus(responseData.Status, http.StatusText(responseData.Status))
+	} else if reader, ok := responseData.Content.(io.Reader); ok {
+		return reader
+	} else if err, ok := responseData.Content.(error); ok {
+		s := protobufStatus(responseData.Status, err.Error())
+		if s.Code == int32(code.Code_OK) {
+			s.Code = int32(code.Code_UNKNOWN)
+		}
+		message = s
+	} else if m, ok := responseData.Content.(proto.Message); ok {
+		message = m
+	} else {
+		panic(fmt.Errorf("failed to marshal Protobuf data: %T is not a proto.Message", responseData.Content))
+	}
+
+	var data []byte
+	var err error
+	if f.json(responseData) {
+		data, err = protojson.Marshal(message)
+	} else {
+		data, err = proto.Marshal(message)
+	}
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal Protobuf data: %w", err))
+	}
+
+	return bytes.NewReader(data)
+}
+
+// FormatHeader formats the response header by setting the Content-Type to
+// "application/x-protobuf", or "application/json" if the JSON fallback is
+// used.
+func (f ProtobufFormatter) FormatHeader(responseData ResponseData) http.Header {
+	if f.json(responseData) {
+		responseData.Header.Set("Content-Type", "application/json")
+	} else {
+		responseData.Header.Set("Content-Type", "application/x-protobuf")
+	}
+	return responseData.Header
+}
+
+// FormatStatus formats the response status. If the status is 0, it will be
+// set to http.StatusOK.
+func (f ProtobufFormatter) FormatStatus(responseData ResponseData) int {
+	if responseData.Status == 0 {
+		return http.StatusOK
+	}
+	return responseData.Status
+}
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f ProtobufFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
+
+// json reports whether the request prefers JSON over the binary wire format.
+func (f ProtobufFormatter) json(responseData ResponseData) bool {
+	if responseData.Request == nil {
+		return false
+	}
+	accept := parseAccept(responseData.Request.Header.Values("Accept"))
+	return accept.quality("application/json") > accept.quality("application/x-protobuf")
+}
+
+// protobufStatus returns a google.rpc.Status message for the given HTTP status.
+func protobufStatus(httpStatus int, message string) *status.Status {
+	return &status.Status{
+		Code:    int32(protobufCode(httpStatus)),
+		Message: message,
+	}
+}
+
+// protobufCode maps an HTTP status code to the closest google.rpc.Code.
+func protobufCode(httpStatus int) code.Code {
+	switch httpStatus {
+	case 0, http.StatusOK:
+		return code.Code_OK
+	case http.StatusBadRequest:
+		return code.Code_INVALID_ARGUMENT
+	case http.StatusUnauthorized:
+		return code.Code_UNAUTHENTICATED
+	case http.StatusForbidden:
+		return code.Code_PERMISSION_DENIED
+	case http.StatusNotFound:
+		return code.Code_NOT_FOUND
+	case http.StatusConflict:
+		return code.Code_ABORTED
+	case http.StatusPreconditionFailed:
+		return code.Code_FAILED_PRECONDITION
+	case http.StatusRequestedRangeNotSatisfiable:
+		return code.Code_OUT_OF_RANGE
+	case http.StatusTooManyRequests:
+		return code.Code_RESOURCE_EXHAUSTED
+	case 499:
+		return code.Code_CANCELLED
+	case http.StatusNotImplemented:
+		return code.Code_UNIMPLEMENTED
+	case http.StatusServiceUnavailable:
+		return code.Code_UNAVAILABLE
+	case http.StatusGatewayTimeout:
+		return code.Code_DEADLINE_EXCEEDED
+	}
+
+	switch {
+	case httpStatus < 400:
+		return code.Code_OK
+	case httpStatus < 500:
+		return code.Code_FAILED_PRECONDITION
+	default:
+		return code.Code_INTERNAL
+	}
+}
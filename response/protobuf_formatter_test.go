@@ -0,0 +1,159 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtobufFormatterFormatBody(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: wrapperspb.String("value"),
+	}
+
+	f := ProtobufFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+
+	message := &wrapperspb.StringValue{}
+	assert.NoError(t, proto.Unmarshal(body, message))
+	assert.Equal(t, "value", message.GetValue())
+}
+
+func TestProtobufFormatterFormatBodyJSONFallback(t *testing.T) {
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.org", nil)
+	request.Header.Set("Accept", "application/json")
+
+	responseData := ResponseData{
+		Content: wrapperspb.String("value"),
+		Header:  http.Header{},
+		Request: request,
+	}
+
+	f := ProtobufFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, `"value"`, string(body))
+	assert.Equal(t, "application/json", f.FormatHeader(responseData).Get("Content-Type"))
+}
+
+func TestProtobufFormatterFormatStream(t *testing.T) {
+
+	body := []byte("body")
+
+	responseData := ResponseData{
+		Content: bytes.NewReader(body),
+	}
+
+	f := ProtobufFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	responseBody, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, responseBody, body)
+}
+
+func TestProtobufFormatterFormatBodyNilContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: nil,
+		Status:  http.StatusNotFound,
+	}
+
+	f := ProtobufFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+
+	message := &status.Status{}
+	assert.NoError(t, proto.Unmarshal(body, message))
+	assert.Equal(t, int32(code.Code_NOT_FOUND), message.GetCode())
+	assert.Equal(t, http.StatusText(http.StatusNotFound), message.GetMessage())
+}
+
+func TestProtobufFormatterFormatBodyErrorContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: errors.New("some error"),
+	}
+
+	f := ProtobufFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+
+	message := &status.Status{}
+	assert.NoError(t, proto.Unmarshal(body, message))
+	assert.Equal(t, int32(code.Code_UNKNOWN), message.GetCode())
+	assert.Equal(t, "some error", message.GetMessage())
+}
+
+func TestProtobufFormatterFormatBodyBadContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: map[string]any{},
+	}
+
+	f := ProtobufFormatter{}
+
+	testPanic := func() {
+		f.FormatBody(responseData)
+	}
+
+	assert.Panics(t, testPanic, "did not panic on non proto.Message content")
+}
+
+func TestProtobufFormatterFormatHeader(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	responseData.Header.Set("key", "value")
+
+	f := ProtobufFormatter{}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "application/x-protobuf", header.Get("Content-Type"))
+	assert.Equal(t, "value", header.Get("key"))
+	assert.Equal(t, 2, len(header))
+}
+
+func TestProtobufFormatterFormatStatusZeroValue(t *testing.T) {
+
+	responseData := ResponseData{}
+
+	f := ProtobufFormatter{}
+
+	status := f.FormatStatus(responseData)
+
+	assert.Equal(t, http.StatusOK, status)
+}
@@ -0,0 +1,591 @@
+// Code generated by gen_status.go; DO NOT EDIT.
+
+package response
+
+import (
+	"net/http"
+	"time"
+)
+
+// OK returns a new response with the status code 200 OK.
+func (r Builder) OK() Builder {
+	return r.WithStatus(http.StatusOK)
+}
+
+// OK returns a 200 OK Builder using DefaultConfig.
+func OK() Builder {
+	return NewBuilder().OK()
+}
+
+// Created returns a new response with the status code 201 Created.
+func (r Builder) Created() Builder {
+	return r.WithStatus(http.StatusCreated)
+}
+
+// Created returns a 201 Created Builder using DefaultConfig.
+func Created() Builder {
+	return NewBuilder().Created()
+}
+
+// Accepted returns a new response with the status code 202 Accepted
+// and the location of a status monitor set as the Location header, unless the
+// location is empty.
+func (r Builder) Accepted(location string) Builder {
+	r = r.WithStatus(http.StatusAccepted)
+	if location != "" {
+		r = r.WithHeaderEntry("Location", location)
+	}
+	return r
+}
+
+// Accepted returns a 202 Accepted Builder using DefaultConfig.
+func Accepted(location string) Builder {
+	return NewBuilder().Accepted(location)
+}
+
+// NonAuthoritativeInfo returns a new response with the status code 203 Non-Authoritative Information.
+func (r Builder) NonAuthoritativeInfo() Builder {
+	return r.WithStatus(http.StatusNonAuthoritativeInfo)
+}
+
+// NonAuthoritativeInfo returns a 203 Non-Authoritative Information Builder using DefaultConfig.
+func NonAuthoritativeInfo() Builder {
+	return NewBuilder().NonAuthoritativeInfo()
+}
+
+// NoContent returns a new response with the status code 204 No Content.
+func (r Builder) NoContent() Builder {
+	return r.WithStatus(http.StatusNoContent)
+}
+
+// NoContent returns a 204 No Content Builder using DefaultConfig.
+func NoContent() Builder {
+	return NewBuilder().NoContent()
+}
+
+// ResetContent returns a new response with the status code 205 Reset Content.
+func (r Builder) ResetContent() Builder {
+	return r.WithStatus(http.StatusResetContent)
+}
+
+// ResetContent returns a 205 Reset Content Builder using DefaultConfig.
+func ResetContent() Builder {
+	return NewBuilder().ResetContent()
+}
+
+// PartialContent returns a new response with the status code 206 Partial Content.
+func (r Builder) PartialContent() Builder {
+	return r.WithStatus(http.StatusPartialContent)
+}
+
+// PartialContent returns a 206 Partial Content Builder using DefaultConfig.
+func PartialContent() Builder {
+	return NewBuilder().PartialContent()
+}
+
+// MultiStatus returns a new response with the status code 207 Multi-Status.
+func (r Builder) MultiStatus() Builder {
+	return r.WithStatus(http.StatusMultiStatus)
+}
+
+// MultiStatus returns a 207 Multi-Status Builder using DefaultConfig.
+func MultiStatus() Builder {
+	return NewBuilder().MultiStatus()
+}
+
+// AlreadyReported returns a new response with the status code 208 Already Reported.
+func (r Builder) AlreadyReported() Builder {
+	return r.WithStatus(http.StatusAlreadyReported)
+}
+
+// AlreadyReported returns a 208 Already Reported Builder using DefaultConfig.
+func AlreadyReported() Builder {
+	return NewBuilder().AlreadyReported()
+}
+
+// IMUsed returns a new response with the status code 226 IM Used.
+func (r Builder) IMUsed() Builder {
+	return r.WithStatus(http.StatusIMUsed)
+}
+
+// IMUsed returns a 226 IM Used Builder using DefaultConfig.
+func IMUsed() Builder {
+	return NewBuilder().IMUsed()
+}
+
+// MultipleChoices returns a new response with the status code 300 Multiple Choices.
+func (r Builder) MultipleChoices() Builder {
+	return r.WithStatus(http.StatusMultipleChoices)
+}
+
+// MultipleChoices returns a 300 Multiple Choices Builder using DefaultConfig.
+func MultipleChoices() Builder {
+	return NewBuilder().MultipleChoices()
+}
+
+// MovedPermanently returns a new response with the status code 301 Moved Permanently
+// and the given location set as the Location header.
+func (r Builder) MovedPermanently(location string) Builder {
+	return r.WithStatus(http.StatusMovedPermanently).WithHeaderEntry("Location", location)
+}
+
+// MovedPermanently returns a 301 Moved Permanently Builder using DefaultConfig.
+func MovedPermanently(location string) Builder {
+	return NewBuilder().MovedPermanently(location)
+}
+
+// Found returns a new response with the status code 302 Found
+// and the given location set as the Location header.
+func (r Builder) Found(location string) Builder {
+	return r.WithStatus(http.StatusFound).WithHeaderEntry("Location", location)
+}
+
+// Found returns a 302 Found Builder using DefaultConfig.
+func Found(location string) Builder {
+	return NewBuilder().Found(location)
+}
+
+// SeeOther returns a new response with the status code 303 See Other
+// and the given location set as the Location header.
+func (r Builder) SeeOther(location string) Builder {
+	return r.WithStatus(http.StatusSeeOther).WithHeaderEntry("Location", location)
+}
+
+// SeeOther returns a 303 See Other Builder using DefaultConfig.
+func SeeOther(location string) Builder {
+	return NewBuilder().SeeOther(location)
+}
+
+// NotModified returns a new response with the status code 304 Not Modified.
+func (r Builder) NotModified() Builder {
+	return r.WithStatus(http.StatusNotModified)
+}
+
+// NotModified returns a 304 Not Modified Builder using DefaultConfig.
+func NotModified() Builder {
+	return NewBuilder().NotModified()
+}
+
+// TemporaryRedirect returns a new response with the status code 307 Temporary Redirect
+// and the given location set as the Location header.
+func (r Builder) TemporaryRedirect(location string) Builder {
+	return r.WithStatus(http.StatusTemporaryRedirect).WithHeaderEntry("Location", location)
+}
+
+// TemporaryRedirect returns a 307 Temporary Redirect Builder using DefaultConfig.
+func TemporaryRedirect(location string) Builder {
+	return NewBuilder().TemporaryRedirect(location)
+}
+
+// PermanentRedirect returns a new response with the status code 308 Permanent Redirect
+// and the given location set as the Location header.
+func (r Builder) PermanentRedirect(location string) Builder {
+	return r.WithStatus(http.StatusPermanentRedirect).WithHeaderEntry("Location", location)
+}
+
+// PermanentRedirect returns a 308 Permanent Redirect Builder using DefaultConfig.
+func PermanentRedirect(location string) Builder {
+	return NewBuilder().PermanentRedirect(location)
+}
+
+// BadRequest returns a new response with the status code 400 Bad Request.
+func (r Builder) BadRequest() Builder {
+	return r.WithStatus(http.StatusBadRequest)
+}
+
+// BadRequest returns a 400 Bad Request Builder using DefaultConfig.
+func BadRequest() Builder {
+	return NewBuilder().BadRequest()
+}
+
+// Unauthorized returns a new response with the status code 401 Unauthorized.
+func (r Builder) Unauthorized() Builder {
+	return r.WithStatus(http.StatusUnauthorized)
+}
+
+// Unauthorized returns a 401 Unauthorized Builder using DefaultConfig.
+func Unauthorized() Builder {
+	return NewBuilder().Unauthorized()
+}
+
+// PaymentRequired returns a new response with the status code 402 Payment Required.
+func (r Builder) PaymentRequired() Builder {
+	return r.WithStatus(http.StatusPaymentRequired)
+}
+
+// PaymentRequired returns a 402 Payment Required Builder using DefaultConfig.
+func PaymentRequired() Builder {
+	return NewBuilder().PaymentRequired()
+}
+
+// Forbidden returns a new response with the status code 403 Forbidden.
+func (r Builder) Forbidden() Builder {
+	return r.WithStatus(http.StatusForbidden)
+}
+
+// Forbidden returns a 403 Forbidden Builder using DefaultConfig.
+func Forbidden() Builder {
+	return NewBuilder().Forbidden()
+}
+
+// NotFound returns a new response with the status code 404 Not Found.
+func (r Builder) NotFound() Builder {
+	return r.WithStatus(http.StatusNotFound)
+}
+
+// NotFound returns a 404 Not Found Builder using DefaultConfig.
+func NotFound() Builder {
+	return NewBuilder().NotFound()
+}
+
+// MethodNotAllowed returns a new response with the status code 405 Method Not Allowed.
+func (r Builder) MethodNotAllowed() Builder {
+	return r.WithStatus(http.StatusMethodNotAllowed)
+}
+
+// MethodNotAllowed returns a 405 Method Not Allowed Builder using DefaultConfig.
+func MethodNotAllowed() Builder {
+	return NewBuilder().MethodNotAllowed()
+}
+
+// NotAcceptable returns a new response with the status code 406 Not Acceptable.
+func (r Builder) NotAcceptable() Builder {
+	return r.WithStatus(http.StatusNotAcceptable)
+}
+
+// NotAcceptable returns a 406 Not Acceptable Builder using DefaultConfig.
+func NotAcceptable() Builder {
+	return NewBuilder().NotAcceptable()
+}
+
+// ProxyAuthRequired returns a new response with the status code 407 Proxy Authentication Required.
+func (r Builder) ProxyAuthRequired() Builder {
+	return r.WithStatus(http.StatusProxyAuthRequired)
+}
+
+// ProxyAuthRequired returns a 407 Proxy Authentication Required Builder using DefaultConfig.
+func ProxyAuthRequired() Builder {
+	return NewBuilder().ProxyAuthRequired()
+}
+
+// RequestTimeout returns a new response with the status code 408 Request Timeout.
+func (r Builder) RequestTimeout() Builder {
+	return r.WithStatus(http.StatusRequestTimeout)
+}
+
+// RequestTimeout returns a 408 Request Timeout Builder using DefaultConfig.
+func RequestTimeout() Builder {
+	return NewBuilder().RequestTimeout()
+}
+
+// Conflict returns a new response with the status code 409 Conflict.
+func (r Builder) Conflict() Builder {
+	return r.WithStatus(http.StatusConflict)
+}
+
+// Conflict returns a 409 Conflict Builder using DefaultConfig.
+func Conflict() Builder {
+	return NewBuilder().Conflict()
+}
+
+// Gone returns a new response with the status code 410 Gone.
+func (r Builder) Gone() Builder {
+	return r.WithStatus(http.StatusGone)
+}
+
+// Gone returns a 410 Gone Builder using DefaultConfig.
+func Gone() Builder {
+	return NewBuilder().Gone()
+}
+
+// LengthRequired returns a new response with the status code 411 Length Required.
+func (r Builder) LengthRequired() Builder {
+	return r.WithStatus(http.StatusLengthRequired)
+}
+
+// LengthRequired returns a 411 Length Required Builder using DefaultConfig.
+func LengthRequired() Builder {
+	return NewBuilder().LengthRequired()
+}
+
+// PreconditionFailed returns a new response with the status code 412 Precondition Failed.
+func (r Builder) PreconditionFailed() Builder {
+	return r.WithStatus(http.StatusPreconditionFailed)
+}
+
+// PreconditionFailed returns a 412 Precondition Failed Builder using DefaultConfig.
+func PreconditionFailed() Builder {
+	return NewBuilder().PreconditionFailed()
+}
+
+// RequestEntityTooLarge returns a new response with the status code 413 Request Entity Too Large.
+func (r Builder) RequestEntityTooLarge() Builder {
+	return r.WithStatus(http.StatusRequestEntityTooLarge)
+}
+
+// RequestEntityTooLarge returns a 413 Request Entity Too Large Builder using DefaultConfig.
+func RequestEntityTooLarge() Builder {
+	return NewBuilder().RequestEntityTooLarge()
+}
+
+// RequestURITooLong returns a new response with the status code 414 Request URI Too Long.
+func (r Builder) RequestURITooLong() Builder {
+	return r.WithStatus(http.StatusRequestURITooLong)
+}
+
+// RequestURITooLong returns a 414 Request URI Too Long Builder using DefaultConfig.
+func RequestURITooLong() Builder {
+	return NewBuilder().RequestURITooLong()
+}
+
+// UnsupportedMediaType returns a new response with the status code 415 Unsupported Media Type.
+func (r Builder) UnsupportedMediaType() Builder {
+	return r.WithStatus(http.StatusUnsupportedMediaType)
+}
+
+// UnsupportedMediaType returns a 415 Unsupported Media Type Builder using DefaultConfig.
+func UnsupportedMediaType() Builder {
+	return NewBuilder().UnsupportedMediaType()
+}
+
+// RequestedRangeNotSatisfiable returns a new response with the status code 416 Requested Range Not Satisfiable.
+func (r Builder) RequestedRangeNotSatisfiable() Builder {
+	return r.WithStatus(http.StatusRequestedRangeNotSatisfiable)
+}
+
+// RequestedRangeNotSatisfiable returns a 416 Requested Range Not Satisfiable Builder using DefaultConfig.
+func RequestedRangeNotSatisfiable() Builder {
+	return NewBuilder().RequestedRangeNotSatisfiable()
+}
+
+// ExpectationFailed returns a new response with the status code 417 Expectation Failed.
+func (r Builder) ExpectationFailed() Builder {
+	return r.WithStatus(http.StatusExpectationFailed)
+}
+
+// ExpectationFailed returns a 417 Expectation Failed Builder using DefaultConfig.
+func ExpectationFailed() Builder {
+	return NewBuilder().ExpectationFailed()
+}
+
+// Teapot returns a new response with the status code 418 I'm a teapot.
+func (r Builder) Teapot() Builder {
+	return r.WithStatus(http.StatusTeapot)
+}
+
+// Teapot returns a 418 I'm a teapot Builder using DefaultConfig.
+func Teapot() Builder {
+	return NewBuilder().Teapot()
+}
+
+// MisdirectedRequest returns a new response with the status code 421 Misdirected Request.
+func (r Builder) MisdirectedRequest() Builder {
+	return r.WithStatus(http.StatusMisdirectedRequest)
+}
+
+// MisdirectedRequest returns a 421 Misdirected Request Builder using DefaultConfig.
+func MisdirectedRequest() Builder {
+	return NewBuilder().MisdirectedRequest()
+}
+
+// UnprocessableEntity returns a new response with the status code 422 Unprocessable Entity.
+func (r Builder) UnprocessableEntity() Builder {
+	return r.WithStatus(http.StatusUnprocessableEntity)
+}
+
+// UnprocessableEntity returns a 422 Unprocessable Entity Builder using DefaultConfig.
+func UnprocessableEntity() Builder {
+	return NewBuilder().UnprocessableEntity()
+}
+
+// Locked returns a new response with the status code 423 Locked.
+func (r Builder) Locked() Builder {
+	return r.WithStatus(http.StatusLocked)
+}
+
+// Locked returns a 423 Locked Builder using DefaultConfig.
+func Locked() Builder {
+	return NewBuilder().Locked()
+}
+
+// FailedDependency returns a new response with the status code 424 Failed Dependency.
+func (r Builder) FailedDependency() Builder {
+	return r.WithStatus(http.StatusFailedDependency)
+}
+
+// FailedDependency returns a 424 Failed Dependency Builder using DefaultConfig.
+func FailedDependency() Builder {
+	return NewBuilder().FailedDependency()
+}
+
+// TooEarly returns a new response with the status code 425 Too Early.
+func (r Builder) TooEarly() Builder {
+	return r.WithStatus(http.StatusTooEarly)
+}
+
+// TooEarly returns a 425 Too Early Builder using DefaultConfig.
+func TooEarly() Builder {
+	return NewBuilder().TooEarly()
+}
+
+// UpgradeRequired returns a new response with the status code 426 Upgrade Required.
+func (r Builder) UpgradeRequired() Builder {
+	return r.WithStatus(http.StatusUpgradeRequired)
+}
+
+// UpgradeRequired returns a 426 Upgrade Required Builder using DefaultConfig.
+func UpgradeRequired() Builder {
+	return NewBuilder().UpgradeRequired()
+}
+
+// PreconditionRequired returns a new response with the status code 428 Precondition Required.
+func (r Builder) PreconditionRequired() Builder {
+	return r.WithStatus(http.StatusPreconditionRequired)
+}
+
+// PreconditionRequired returns a 428 Precondition Required Builder using DefaultConfig.
+func PreconditionRequired() Builder {
+	return NewBuilder().PreconditionRequired()
+}
+
+// TooManyRequests returns a new response with the status code 429 Too Many Requests
+// and the Retry-After header set to the given duration, unless it is zero.
+func (r Builder) TooManyRequests(retryAfter time.Duration) Builder {
+	return r.WithStatus(http.StatusTooManyRequests).withRetryAfter(retryAfter)
+}
+
+// TooManyRequests returns a 429 Too Many Requests Builder using DefaultConfig.
+func TooManyRequests(retryAfter time.Duration) Builder {
+	return NewBuilder().TooManyRequests(retryAfter)
+}
+
+// RequestHeaderFieldsTooLarge returns a new response with the status code 431 Request Header Fields Too Large.
+func (r Builder) RequestHeaderFieldsTooLarge() Builder {
+	return r.WithStatus(http.StatusRequestHeaderFieldsTooLarge)
+}
+
+// RequestHeaderFieldsTooLarge returns a 431 Request Header Fields Too Large Builder using DefaultConfig.
+func RequestHeaderFieldsTooLarge() Builder {
+	return NewBuilder().RequestHeaderFieldsTooLarge()
+}
+
+// UnavailableForLegalReasons returns a new response with the status code 451 Unavailable For Legal Reasons.
+func (r Builder) UnavailableForLegalReasons() Builder {
+	return r.WithStatus(http.StatusUnavailableForLegalReasons)
+}
+
+// UnavailableForLegalReasons returns a 451 Unavailable For Legal Reasons Builder using DefaultConfig.
+func UnavailableForLegalReasons() Builder {
+	return NewBuilder().UnavailableForLegalReasons()
+}
+
+// InternalServerError returns a new response with the status code 500 Internal Server Error.
+func (r Builder) InternalServerError() Builder {
+	return r.WithStatus(http.StatusInternalServerError)
+}
+
+// InternalServerError returns a 500 Internal Server Error Builder using DefaultConfig.
+func InternalServerError() Builder {
+	return NewBuilder().InternalServerError()
+}
+
+// NotImplemented returns a new response with the status code 501 Not Implemented.
+func (r Builder) NotImplemented() Builder {
+	return r.WithStatus(http.StatusNotImplemented)
+}
+
+// NotImplemented returns a 501 Not Implemented Builder using DefaultConfig.
+func NotImplemented() Builder {
+	return NewBuilder().NotImplemented()
+}
+
+// BadGateway returns a new response with the status code 502 Bad Gateway.
+func (r Builder) BadGateway() Builder {
+	return r.WithStatus(http.StatusBadGateway)
+}
+
+// BadGateway returns a 502 Bad Gateway Builder using DefaultConfig.
+func BadGateway() Builder {
+	return NewBuilder().BadGateway()
+}
+
+// ServiceUnavailable returns a new response with the status code 503 Service Unavailable
+// and the Retry-After header set to the given duration, unless it is zero.
+func (r Builder) ServiceUnavailable(retryAfter time.Duration) Builder {
+	return r.WithStatus(http.StatusServiceUnavailable).withRetryAfter(retryAfter)
+}
+
+// ServiceUnavailable returns a 503 Service Unavailable Builder using DefaultConfig.
+func ServiceUnavailable(retryAfter time.Duration) Builder {
+	return NewBuilder().ServiceUnavailable(retryAfter)
+}
+
+// GatewayTimeout returns a new response with the status code 504 Gateway Timeout.
+func (r Builder) GatewayTimeout() Builder {
+	return r.WithStatus(http.StatusGatewayTimeout)
+}
+
+// GatewayTimeout returns a 504 Gateway Timeout Builder using DefaultConfig.
+func GatewayTimeout() Builder {
+	return NewBuilder().GatewayTimeout()
+}
+
+// HTTPVersionNotSupported returns a new response with the status code 505 HTTP Version Not Supported.
+func (r Builder) HTTPVersionNotSupported() Builder {
+	return r.WithStatus(http.StatusHTTPVersionNotSupported)
+}
+
+// HTTPVersionNotSupported returns a 505 HTTP Version Not Supported Builder using DefaultConfig.
+func HTTPVersionNotSupported() Builder {
+	return NewBuilder().HTTPVersionNotSupported()
+}
+
+// VariantAlsoNegotiates returns a new response with the status code 506 Variant Also Negotiates.
+func (r Builder) VariantAlsoNegotiates() Builder {
+	return r.WithStatus(http.StatusVariantAlsoNegotiates)
+}
+
+// VariantAlsoNegotiates returns a 506 Variant Also Negotiates Builder using DefaultConfig.
+func VariantAlsoNegotiates() Builder {
+	return NewBuilder().VariantAlsoNegotiates()
+}
+
+// InsufficientStorage returns a new response with the status code 507 Insufficient Storage.
+func (r Builder) InsufficientStorage() Builder {
+	return r.WithStatus(http.StatusInsufficientStorage)
+}
+
+// InsufficientStorage returns a 507 Insufficient Storage Builder using DefaultConfig.
+func InsufficientStorage() Builder {
+	return NewBuilder().InsufficientStorage()
+}
+
+// LoopDetected returns a new response with the status code 508 Loop Detected.
+func (r Builder) LoopDetected() Builder {
+	return r.WithStatus(http.StatusLoopDetected)
+}
+
+// LoopDetected returns a 508 Loop Detected Builder using DefaultConfig.
+func LoopDetected() Builder {
+	return NewBuilder().LoopDetected()
+}
+
+// NotExtended returns a new response with the status code 510 Not Extended.
+func (r Builder) NotExtended() Builder {
+	return r.WithStatus(http.StatusNotExtended)
+}
+
+// NotExtended returns a 510 Not Extended Builder using DefaultConfig.
+func NotExtended() Builder {
+	return NewBuilder().NotExtended()
+}
+
+// NetworkAuthenticationRequired returns a new response with the status code 511 Network Authentication Required.
+func (r Builder) NetworkAuthenticationRequired() Builder {
+	return r.WithStatus(http.StatusNetworkAuthenticationRequired)
+}
+
+// NetworkAuthenticationRequired returns a 511 Network Authentication Required Builder using DefaultConfig.
+func NetworkAuthenticationRequired() Builder {
+	return NewBuilder().NetworkAuthenticationRequired()
+}
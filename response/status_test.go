@@ -0,0 +1,77 @@
+package response
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusHelpers(t *testing.T) {
+	tests := []struct {
+		builder Builder
+		status  int
+	}{
+		{Accepted(""), http.StatusAccepted},
+		{PartialContent(), http.StatusPartialContent},
+		{SeeOther("/orders/1"), http.StatusSeeOther},
+		{Gone(), http.StatusGone},
+		{MethodNotAllowed(), http.StatusMethodNotAllowed},
+		{UnprocessableEntity(), http.StatusUnprocessableEntity},
+		{TooManyRequests(0), http.StatusTooManyRequests},
+		{ServiceUnavailable(0), http.StatusServiceUnavailable},
+		{NetworkAuthenticationRequired(), http.StatusNetworkAuthenticationRequired},
+		{NewBuilder().Teapot(), http.StatusTeapot},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.status, test.builder.Status())
+	}
+}
+
+func TestAccepted(t *testing.T) {
+	assert.Equal(t, "/jobs/1", Accepted("/jobs/1").Header().Get("Location"))
+	assert.Empty(t, Accepted("").Header().Values("Location"))
+}
+
+func TestSeeOther(t *testing.T) {
+	assert.Equal(t, "/orders/1", SeeOther("/orders/1").Header().Get("Location"))
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, "120", TooManyRequests(2*time.Minute).Header().Get("Retry-After"))
+	assert.Equal(t, "2", ServiceUnavailable(1500*time.Millisecond).Header().Get("Retry-After"))
+	assert.Empty(t, ServiceUnavailable(0).Header().Values("Retry-After"))
+}
+
+func TestBodylessStatuses(t *testing.T) {
+	formatters := []Formatter{
+		JSONFormatter{},
+		XMLFormatter{},
+		PlainTextFormatter{},
+		YAMLFormatter{},
+		CSVFormatter{},
+	}
+
+	for _, formatter := range formatters {
+		r := NewBuilder(WithConfig(Config{Formatter: formatter}))
+
+		for _, res := range []Builder{
+			r.NoContent(),
+			r.NoContent().WithContent(errors.New("ignored")),
+			r.ResetContent(),
+			r.NotModified().WithHeaderEntry("Etag", `"1"`),
+		} {
+			body, err := io.ReadAll(res.Body())
+			assert.NoError(t, err, "failed to read body reader")
+
+			assert.Empty(t, body)
+			assert.Empty(t, res.Header().Get("Content-Type"))
+		}
+
+		assert.Equal(t, `"1"`, r.NotModified().WithHeaderEntry("Etag", `"1"`).Header().Get("Etag"))
+	}
+}
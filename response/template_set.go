@@ -0,0 +1,191 @@
+package response
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// TemplateSet is a set of HTML page templates loaded from a fs.FS. Every page
+// is parsed together with the layout templates, which allows pages to invoke a
+// base layout and fill in the blocks it defines. Templates are named by their
+// path within the file system.
+type TemplateSet struct {
+	fsys    fs.FS
+	layouts []string
+	pages   []string
+	funcs   template.FuncMap
+	reload  bool
+
+	mu          sync.RWMutex
+	templates   map[string]*template.Template
+	fingerprint string
+}
+
+// TemplateSetOption is a functional option for configuring a template set.
+type TemplateSetOption func(*TemplateSet)
+
+// WithTemplateFuncs adds the given functions to the templates of the set.
+func WithTemplateFuncs(funcs template.FuncMap) TemplateSetOption {
+	return func(s *TemplateSet) {
+		for name, fn := range funcs {
+			s.funcs[name] = fn
+		}
+	}
+}
+
+// WithTemplateReload configures whether the set is reloaded when the template
+// files change. Reloading requires checking the files on every lookup and is
+// intended for development.
+func WithTemplateReload(reload bool) TemplateSetOption {
+	return func(s *TemplateSet) {
+		s.reload = reload
+	}
+}
+
+// NewTemplateSet loads a template set from fsys. Layouts and pages are glob
+// patterns as supported by fs.Glob. Files matching the layout patterns are
+// shared by all pages, while each file matching the page patterns becomes a
+// page template of its own.
+func NewTemplateSet(fsys fs.FS, layouts []string, pages []string, options ...TemplateSetOption) (*TemplateSet, error) {
+	s := &TemplateSet{
+		fsys:    fsys,
+		layouts: layouts,
+		pages:   pages,
+		funcs:   template.FuncMap{},
+	}
+
+	for _, opt := range options {
+		opt(s)
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Lookup returns the page template with the given name. If the set is
+// configured to reload, the templates are parsed again if any of the files
+// have changed since they were last loaded, which serializes lookups.
+// Otherwise lookups run concurrently.
+func (s *TemplateSet) Lookup(name string) (*template.Template, error) {
+	if s.reload {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		fingerprint, err := s.currentFingerprint()
+		if err != nil {
+			return nil, err
+		}
+		if fingerprint != s.fingerprint {
+			if err := s.parse(fingerprint); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+
+	t, ok := s.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("template %q not found", name)
+	}
+	return t, nil
+}
+
+// load parses all templates of the set.
+func (s *TemplateSet) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fingerprint, err := s.currentFingerprint()
+	if err != nil {
+		return err
+	}
+	return s.parse(fingerprint)
+}
+
+// parse parses all templates of the set and records the fingerprint of the
+// files they were parsed from.
+func (s *TemplateSet) parse(fingerprint string) error {
+	layoutFiles, err := globTemplates(s.fsys, s.layouts)
+	if err != nil {
+		return err
+	}
+	pageFiles, err := globTemplates(s.fsys, s.pages)
+	if err != nil {
+		return err
+	}
+
+	base := template.New("").Funcs(s.funcs)
+	for _, file := range layoutFiles {
+		if err := parseTemplateFile(s.fsys, base, file); err != nil {
+			return err
+		}
+	}
+
+	templates := make(map[string]*template.Template, len(pageFiles))
+	for _, file := range pageFiles {
+		page, err := base.Clone()
+		if err != nil {
+			return fmt.Errorf("failed to clone layouts: %w", err)
+		}
+		if err := parseTemplateFile(s.fsys, page, file); err != nil {
+			return err
+		}
+		templates[file] = page
+	}
+
+	s.templates = templates
+	s.fingerprint = fingerprint
+	return nil
+}
+
+// currentFingerprint returns a fingerprint of the names, sizes and modification times of
+// the files of the set.
+func (s *TemplateSet) currentFingerprint() (string, error) {
+	files, err := globTemplates(s.fsys, append(append([]string{}, s.layouts...), s.pages...))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, file := range files {
+		info, err := fs.Stat(s.fsys, file)
+		if err != nil {
+			return "", fmt.Errorf("failed to stat template %q: %w", file, err)
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String(), nil
+}
+
+// globTemplates returns the files in fsys matching any of the patterns.
+func globTemplates(fsys fs.FS, patterns []string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template pattern %q: %w", pattern, err)
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// parseTemplateFile parses the given file into a new template associated with t.
+func parseTemplateFile(fsys fs.FS, t *template.Template, file string) error {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return fmt.Errorf("failed to read template %q: %w", file, err)
+	}
+	if _, err := t.New(file).Parse(string(data)); err != nil {
+		return fmt.Errorf("failed to parse template %q: %w", file, err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package response
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func templateSetFS() fstest.MapFS {
+	return fstest.MapFS{
+		"layouts/base.html": &fstest.MapFile{
+			Data: []byte(`<html><title>{{block "title" .}}Default{{end}}</title><body>{{block "content" .}}{{end}}</body></html>`),
+		},
+		"pages/index.html": &fstest.MapFile{
+			Data: []byte(`{{define "title"}}Index{{end}}{{define "content"}}{{upper .}}{{end}}{{template "layouts/base.html" .}}`),
+		},
+		"pages/about.html": &fstest.MapFile{
+			Data: []byte(`{{define "content"}}About{{end}}{{template "layouts/base.html" .}}`),
+		},
+	}
+}
+
+func executeTemplateSet(t *testing.T, s *TemplateSet, name string, data any) string {
+	page, err := s.Lookup(name)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, page.ExecuteTemplate(&buf, name, data))
+	return buf.String()
+}
+
+func TestTemplateSetLayouts(t *testing.T) {
+
+	s, err := NewTemplateSet(templateSetFS(), []string{"layouts/*.html"}, []string{"pages/*.html"},
+		WithTemplateFuncs(template.FuncMap{"upper": strings.ToUpper}),
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "<html><title>Index</title><body>VALUE</body></html>", executeTemplateSet(t, s, "pages/index.html", "value"))
+	assert.Equal(t, "<html><title>Default</title><body>About</body></html>", executeTemplateSet(t, s, "pages/about.html", nil))
+}
+
+func TestTemplateSetConcurrentLookup(t *testing.T) {
+
+	for _, reload := range []bool{false, true} {
+		s, err := NewTemplateSet(templateSetFS(), []string{"layouts/*.html"}, []string{"pages/about.html"}, WithTemplateReload(reload))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := s.Lookup("pages/about.html")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestTemplateSetLookupUnknown(t *testing.T) {
+
+	s, err := NewTemplateSet(templateSetFS(), []string{"layouts/*.html"}, []string{"pages/about.html"})
+	assert.NoError(t, err)
+
+	_, err = s.Lookup("pages/index.html")
+	assert.Error(t, err)
+}
+
+func TestTemplateSetParseError(t *testing.T) {
+
+	fsys := templateSetFS()
+	fsys["pages/broken.html"] = &fstest.MapFile{Data: []byte(`{{if}}`)}
+
+	_, err := NewTemplateSet(fsys, []string{"layouts/*.html"}, []string{"pages/*.html"},
+		WithTemplateFuncs(template.FuncMap{"upper": strings.ToUpper}),
+	)
+	assert.Error(t, err)
+}
+
+func TestTemplateSetReload(t *testing.T) {
+
+	fsys := templateSetFS()
+
+	s, err := NewTemplateSet(fsys, []string{"layouts/*.html"}, []string{"pages/about.html"}, WithTemplateReload(true))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "<html><title>Default</title><body>About</body></html>", executeTemplateSet(t, s, "pages/about.html", nil))
+
+	fsys["pages/about.html"] = &fstest.MapFile{
+		Data:    []byte(`{{define "content"}}About us{{end}}{{template "layouts/base.html" .}}`),
+		ModTime: time.Now(),
+	}
+
+	assert.Equal(t, "<html><title>Default</title><body>About us</body></html>", executeTemplateSet(t, s, "pages/about.html", nil))
+}
@@ -0,0 +1,73 @@
+package response
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+)
+
+// TextTemplateFormatter is a ResponseFormatter that formats responses using Go
+// text templates. Unlike HTMLTemplateFormatter, the output is not escaped,
+// which makes it suitable for plain text, Markdown or e-mail like output.
+type TextTemplateFormatter struct {
+	Template *template.Template
+	// ContentType is the Content-Type of the formatted response. Defaults to
+	// "text/plain; charset=utf-8".
+	ContentType string
+}
+
+// FormatBody executes the template with the response content as data. If the
+// response body is an io.Reader, it will be returned as is. Template execution
+// errors are returned when reading the body, as is an error if no template is
+// set.
+func (f TextTemplateFormatter) FormatBody(responseData ResponseData) io.Reader {
+
+	if reader, ok := responseData.Content.(io.Reader); ok {
+		return reader
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+
+	if f.Template == nil {
+		pipeWriter.CloseWithError(errNoTemplate)
+		return pipeReader
+	}
+
+	go func() {
+		defer pipeWriter.Close()
+
+		err := f.Template.Execute(pipeWriter, responseData.Content)
+		if err != nil {
+			pipeWriter.CloseWithError(fmt.Errorf("failed to execute template: %w", err))
+		}
+	}()
+
+	return pipeReader
+}
+
+// FormatHeader formats the response header by setting the Content-Type to
+// f.ContentType, or "text/plain; charset=utf-8" if it is empty.
+func (f TextTemplateFormatter) FormatHeader(responseData ResponseData) http.Header {
+	contentType := f.ContentType
+	if contentType == "" {
+		contentType = "text/plain; charset=utf-8"
+	}
+	responseData.Header.Set("Content-Type", contentType)
+	return responseData.Header
+}
+
+// FormatStatus formats the response status. If the status is 0, it will be
+// set to http.StatusOK.
+func (f TextTemplateFormatter) FormatStatus(responseData ResponseData) int {
+	if responseData.Status == 0 {
+		return http.StatusOK
+	}
+	return responseData.Status
+}
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f TextTemplateFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
@@ -0,0 +1,129 @@
+package response
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextTemplateFormatterFormatBody(t *testing.T) {
+
+	template, err := template.New("test").Parse("# {{.Title}}\n\n<b>{{.Body}}</b>\n")
+
+	assert.NoError(t, err, "failed to parse template")
+
+	type textData struct {
+		Title string
+		Body  string
+	}
+
+	responseData := ResponseData{
+		Content: textData{
+			Title: "Title",
+			Body:  "a & b",
+		},
+	}
+
+	f := TextTemplateFormatter{
+		Template: template,
+	}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "# Title\n\n<b>a & b</b>\n", string(body))
+}
+
+func TestTextTemplateFormatterFormatBodyBadTemplate(t *testing.T) {
+
+	template, err := template.New("test").Parse("{{.Wrong}}")
+
+	assert.NoError(t, err, "failed to parse template")
+
+	responseData := ResponseData{
+		Content: struct{ Key string }{Key: "value"},
+	}
+
+	f := TextTemplateFormatter{
+		Template: template,
+	}
+
+	bodyReader := f.FormatBody(responseData)
+	_, err = io.ReadAll(bodyReader)
+
+	assert.Error(t, err)
+}
+
+func TestTextTemplateFormatterFormatBodyNoTemplate(t *testing.T) {
+
+	_, err := io.ReadAll(TextTemplateFormatter{}.FormatBody(ResponseData{Content: "content"}))
+
+	assert.ErrorIs(t, err, errNoTemplate)
+}
+
+func TestTextTemplateFormatterFormatStream(t *testing.T) {
+
+	body := []byte("body")
+
+	responseData := ResponseData{
+		Content: bytes.NewReader(body),
+	}
+
+	f := TextTemplateFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	responseBody, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, responseBody, body)
+}
+
+func TestTextTemplateFormatterFormatHeader(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	responseData.Header.Set("key", "value")
+
+	f := TextTemplateFormatter{}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "text/plain; charset=utf-8", header.Get("Content-Type"))
+	assert.Equal(t, "value", header.Get("key"))
+	assert.Equal(t, 2, len(header))
+}
+
+func TestTextTemplateFormatterFormatHeaderContentType(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	f := TextTemplateFormatter{
+		ContentType: "text/markdown; charset=utf-8",
+	}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "text/markdown; charset=utf-8", header.Get("Content-Type"))
+}
+
+func TestTextTemplateFormatterFormatStatusZeroValue(t *testing.T) {
+
+	responseData := ResponseData{}
+
+	f := TextTemplateFormatter{}
+
+	status := f.FormatStatus(responseData)
+
+	assert.Equal(t, http.StatusOK, status)
+}
@@ -0,0 +1,131 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOMLFormatterFormatBody(t *testing.T) {
+
+	type tomlTest struct {
+		Key  string   `toml:"key"`
+		List []string `toml:"list"`
+	}
+
+	responseData := ResponseData{
+		Content: tomlTest{
+			Key:  "value",
+			List: []string{"a", "b"},
+		},
+	}
+
+	f := TOMLFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "key = \"value\"\nlist = [\"a\", \"b\"]\n", string(body))
+}
+
+func TestTOMLFormatterFormatStream(t *testing.T) {
+
+	body := []byte("body")
+
+	responseData := ResponseData{
+		Content: bytes.NewReader(body),
+	}
+
+	f := TOMLFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	responseBody, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, responseBody, body)
+}
+
+func TestTOMLFormatterFormatBodyNilContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: nil,
+		Status:  http.StatusBadRequest,
+	}
+
+	f := TOMLFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, fmt.Sprintf("message = %q\n", http.StatusText(responseData.Status)), string(body))
+}
+
+func TestTOMLFormatterFormatBodyErrorContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: errors.New("some error"),
+		Status:  http.StatusBadRequest,
+	}
+
+	f := TOMLFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "message = \"some error\"\n", string(body))
+}
+
+func TestTOMLFormatterFormatBodyBadContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: []string{"not", "a", "table"},
+	}
+
+	f := TOMLFormatter{}
+
+	testPanic := func() {
+		f.FormatBody(responseData)
+	}
+
+	assert.Panics(t, testPanic, "did not panic on bad TOML marshalling input type")
+}
+
+func TestTOMLFormatterFormatHeader(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	responseData.Header.Set("key", "value")
+
+	f := TOMLFormatter{}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "application/toml", header.Get("Content-Type"))
+	assert.Equal(t, "value", header.Get("key"))
+	assert.Equal(t, 2, len(header))
+}
+
+func TestTOMLFormatterFormatStatusZeroValue(t *testing.T) {
+
+	responseData := ResponseData{}
+
+	f := TOMLFormatter{}
+
+	status := f.FormatStatus(responseData)
+
+	assert.Equal(t, http.StatusOK, status)
+}
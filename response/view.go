@@ -0,0 +1,148 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// View is the data passed to templates by HTMLTemplateFormatter when its View
+// option is enabled. It merges the response content with the request being
+// responded to and the request-scoped values added using WithViewValue.
+type View struct {
+	// Content is the content of the response.
+	Content any
+	// Request is the request being responded to, or nil if the response has
+	// not been bound to a request.
+	Request *http.Request
+	// Values contains the request-scoped values added using WithViewValue.
+	Values map[string]any
+}
+
+type viewValuesKey struct{}
+
+// WithViewValue returns a shallow copy of r with the given value added to the
+// values exposed to templates through View.Values. It is intended to be used
+// by middleware to provide things like CSRF tokens, the current user or the
+// locale to every template.
+func WithViewValue(r *http.Request, key string, value any) *http.Request {
+	current := ViewValues(r.Context())
+
+	values := make(map[string]any, len(current)+1)
+	for k, v := range current {
+		values[k] = v
+	}
+	values[key] = value
+
+	return r.WithContext(context.WithValue(r.Context(), viewValuesKey{}, values))
+}
+
+// ViewValues returns the view values added to the context using WithViewValue.
+// The returned map must not be modified.
+func ViewValues(ctx context.Context) map[string]any {
+	values, _ := ctx.Value(viewValuesKey{}).(map[string]any)
+	return values
+}
+
+// newView returns the View for the given response data.
+func newView(responseData ResponseData) View {
+	view := View{
+		Content: responseData.Content,
+		Request: responseData.Request,
+	}
+	if responseData.Request != nil {
+		view.Values = ViewValues(responseData.Request.Context())
+	}
+	return view
+}
+
+// TemplateFuncs returns a template.FuncMap with commonly used helper functions:
+//
+//   - url builds a URL from a path and query key/value pairs, e.g.
+//     {{url "/search" "q" .Query "page" 2}}
+//   - asset joins the given asset path with assetBase, e.g.
+//     {{asset "css/site.css"}}
+//   - date formats a time.Time using a time layout, e.g.
+//     {{date .Created "2006-01-02"}}
+//   - number formats a number with thousands separators and the given number
+//     of decimals, e.g. {{number .Total 2}}
+func TemplateFuncs(assetBase string) template.FuncMap {
+	return template.FuncMap{
+		"url": templateURL,
+		"asset": func(p string) string {
+			if strings.Contains(assetBase, "://") {
+				return strings.TrimSuffix(assetBase, "/") + "/" + strings.TrimPrefix(p, "/")
+			}
+			return path.Join("/", assetBase, p)
+		},
+		"date": func(t time.Time, layout string) string {
+			return t.Format(layout)
+		},
+		"number": templateNumber,
+	}
+}
+
+// templateURL builds a URL from the given path and query key/value pairs.
+func templateURL(p string, pairs ...any) (string, error) {
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("url: odd number of query arguments")
+	}
+
+	u, err := url.Parse(p)
+	if err != nil {
+		return "", fmt.Errorf("url: %w", err)
+	}
+
+	query := u.Query()
+	for i := 0; i < len(pairs); i += 2 {
+		query.Add(fmt.Sprint(pairs[i]), fmt.Sprint(pairs[i+1]))
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// templateNumber formats the given number with thousands separators and the
+// given number of decimals.
+func templateNumber(v any, decimals int) (string, error) {
+	var f float64
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f = float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = rv.Float()
+	default:
+		return "", fmt.Errorf("number: unsupported type %T", v)
+	}
+
+	s := strconv.FormatFloat(math.Abs(f), 'f', decimals, 64)
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+
+	var b strings.Builder
+	if f < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	if hasFraction {
+		b.WriteByte('.')
+		b.WriteString(fraction)
+	}
+	return b.String(), nil
+}
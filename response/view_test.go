@@ -0,0 +1,100 @@
+package response
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithViewValue(t *testing.T) {
+
+	r := httptest.NewRequest(http.MethodGet, "http://example.org", nil)
+
+	r1 := WithViewValue(r, "a", 1)
+	r2 := WithViewValue(r1, "b", 2)
+
+	assert.Nil(t, ViewValues(r.Context()))
+	assert.Equal(t, map[string]any{"a": 1}, ViewValues(r1.Context()))
+	assert.Equal(t, map[string]any{"a": 1, "b": 2}, ViewValues(r2.Context()))
+}
+
+func TestHTMLTemplateFormatterFormatBodyView(t *testing.T) {
+
+	template, err := template.New("test").Parse(
+		`<form action="{{.Request.URL.Path}}"><input value="{{.Values.csrf}}">{{.Content.Key}}</form>`,
+	)
+
+	assert.NoError(t, err, "failed to parse template")
+
+	r := httptest.NewRequest(http.MethodGet, "http://example.org/path", nil)
+	r = WithViewValue(r, "csrf", "token")
+
+	responseData := ResponseData{
+		Content: struct{ Key string }{Key: "value"},
+		Request: r,
+	}
+
+	f := HTMLTemplateFormatter{
+		Template: template,
+		View:     true,
+	}
+
+	body, err := io.ReadAll(f.FormatBody(responseData))
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, `<form action="/path"><input value="token">value</form>`, string(body))
+}
+
+func TestTemplateFuncs(t *testing.T) {
+
+	tests := map[string]string{
+		`{{url "/search" "q" "a b" "page" 2}}`:   "/search?page=2&amp;q=a&#43;b",
+		`<a href="{{url "/search" "q" "a&b"}}">`: `<a href="/search?q=a%26b">`,
+		`{{asset "css/site.css"}}`:               "/static/css/site.css",
+		`{{date .Time "2006-01-02"}}`:            "2023-05-01",
+		`{{number 1234567 0}}`:                   "1,234,567",
+		`{{number -1234.5 2}}`:                   "-1,234.50",
+		`{{number 999 1}}`:                       "999.0",
+		`{{number .Uint 0}}`:                     "1,000",
+	}
+
+	data := struct {
+		Time time.Time
+		Uint uint
+	}{
+		Time: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+		Uint: 1000,
+	}
+
+	for text, expected := range tests {
+		tmpl, err := template.New("test").Funcs(TemplateFuncs("static")).Parse(text)
+		assert.NoError(t, err, text)
+
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Execute(&buf, data), text)
+		assert.Equal(t, expected, buf.String(), text)
+	}
+}
+
+func TestTemplateFuncsAssetURL(t *testing.T) {
+
+	asset := TemplateFuncs("https://cdn.example.org/")["asset"].(func(string) string)
+
+	assert.Equal(t, "https://cdn.example.org/css/site.css", asset("/css/site.css"))
+}
+
+func TestTemplateFuncsErrors(t *testing.T) {
+
+	for _, text := range []string{`{{url "/search" "q"}}`, `{{number "1" 0}}`} {
+		tmpl, err := template.New("test").Funcs(TemplateFuncs("")).Parse(text)
+		assert.NoError(t, err, text)
+
+		assert.Error(t, tmpl.Execute(io.Discard, nil), text)
+	}
+}
@@ -60,3 +60,9 @@ func (f XMLFormatter) FormatStatus(responseData ResponseData) int {
 	}
 	return responseData.Status
 }
+
+// ContentLength returns the length of io.Reader content reporting its length,
+// as such content is written as is.
+func (f XMLFormatter) ContentLength(responseData ResponseData) (int64, bool) {
+	return readerLength(responseData.Content)
+}
@@ -0,0 +1,131 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYAMLFormatterFormatBody(t *testing.T) {
+
+	type yamlTest struct {
+		Key  string   `yaml:"key"`
+		List []string `yaml:"list"`
+	}
+
+	responseData := ResponseData{
+		Content: yamlTest{
+			Key:  "value",
+			List: []string{"a", "b"},
+		},
+	}
+
+	f := YAMLFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "key: value\nlist:\n    - a\n    - b\n", string(body))
+}
+
+func TestYAMLFormatterFormatStream(t *testing.T) {
+
+	body := []byte("body")
+
+	responseData := ResponseData{
+		Content: bytes.NewReader(body),
+	}
+
+	f := YAMLFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	responseBody, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, responseBody, body)
+}
+
+func TestYAMLFormatterFormatBodyNilContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: nil,
+		Status:  http.StatusBadRequest,
+	}
+
+	f := YAMLFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, fmt.Sprintf("message: %v\n", http.StatusText(responseData.Status)), string(body))
+}
+
+func TestYAMLFormatterFormatBodyErrorContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: errors.New("some error"),
+		Status:  http.StatusBadRequest,
+	}
+
+	f := YAMLFormatter{}
+
+	bodyReader := f.FormatBody(responseData)
+
+	body, err := io.ReadAll(bodyReader)
+
+	assert.NoError(t, err, "failed to read body reader")
+	assert.Equal(t, "message: some error\n", string(body))
+}
+
+func TestYAMLFormatterFormatBodyBadContent(t *testing.T) {
+
+	responseData := ResponseData{
+		Content: func() {},
+	}
+
+	f := YAMLFormatter{}
+
+	testPanic := func() {
+		f.FormatBody(responseData)
+	}
+
+	assert.Panics(t, testPanic, "did not panic on bad YAML marshalling input type")
+}
+
+func TestYAMLFormatterFormatHeader(t *testing.T) {
+
+	responseData := ResponseData{
+		Header: http.Header{},
+	}
+
+	responseData.Header.Set("key", "value")
+
+	f := YAMLFormatter{}
+
+	header := f.FormatHeader(responseData)
+
+	assert.Equal(t, "application/yaml", header.Get("Content-Type"))
+	assert.Equal(t, "value", header.Get("key"))
+	assert.Equal(t, 2, len(header))
+}
+
+func TestYAMLFormatterFormatStatusZeroValue(t *testing.T) {
+
+	responseData := ResponseData{}
+
+	f := YAMLFormatter{}
+
+	status := f.FormatStatus(responseData)
+
+	assert.Equal(t, http.StatusOK, status)
+}
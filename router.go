@@ -0,0 +1,450 @@
+package recoil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/jhdrn/go-recoil/response"
+)
+
+// Middleware wraps a Handler to run code before or after it.
+type Middleware func(Handler) Handler
+
+// Route is a route registered on a Router.
+type Route struct {
+	// Method is the HTTP method of the route.
+	Method string
+	// Pattern is the full path pattern of the route.
+	Pattern string
+
+	handler  Handler
+	segments []string
+	metadata map[any]any
+}
+
+// WithMetadata associates the value with the key on the route, allowing other
+// packages to annotate routes, and returns the route. Like context keys, keys
+// should be of an unexported type to avoid collisions.
+func (route *Route) WithMetadata(key any, value any) *Route {
+	if route.metadata == nil {
+		route.metadata = map[any]any{}
+	}
+	route.metadata[key] = value
+	return route
+}
+
+// Metadata returns the value associated with the key on the route, or nil if
+// there is none.
+func (route *Route) Metadata(key any) any {
+	return route.metadata[key]
+}
+
+// routeTable contains the routes shared by a router and its groups.
+type routeTable struct {
+	routes           []*Route
+	notFound         Handler
+	methodNotAllowed Handler
+}
+
+// Router is an http.Handler which routes requests to Handlers by method and
+// path pattern.
+//
+// Patterns consist of slash separated segments. A segment enclosed in braces,
+// like "{id}", matches any single segment and captures it as a parameter. A
+// final "*" segment matches the rest of the path, which is captured as the "*"
+// parameter. Static segments take precedence over parameters, which take
+// precedence over wildcards. Captured parameters are read using Param.
+//
+// Requests matching no route get a 404 Not Found response. Requests matching a
+// route for another method get a 405 Method Not Allowed response with the
+// Allow header set. Both are formatted using the router's response config.
+//
+// HEAD requests are routed to GET routes unless a HEAD route matches. OPTIONS
+// requests are answered with a 204 No Content response listing the allowed
+// methods in the Allow header unless an OPTIONS route matches.
+type Router struct {
+	table      *routeTable
+	config     *response.Config
+	prefix     string
+	middleware []Middleware
+}
+
+// RouterOption is a functional option for configuring a router.
+type RouterOption func(*Router)
+
+// WithResponseConfig configures the router to use the given response config
+// for the responses it creates itself, such as 404 Not Found. By default
+// response.DefaultConfig is used.
+func WithResponseConfig(c response.Config) RouterOption {
+	return func(router *Router) {
+		router.config = &c
+	}
+}
+
+// NewRouter returns a new router.
+func NewRouter(options ...RouterOption) *Router {
+	router := &Router{
+		table: &routeTable{},
+	}
+
+	for _, opt := range options {
+		opt(router)
+	}
+
+	return router
+}
+
+// Use appends middleware to the router. The middleware applies to the routes
+// registered on the router and its groups afterwards, and to the not found and
+// method not allowed responses.
+func (router *Router) Use(middleware ...Middleware) {
+	router.middleware = append(router.middleware, middleware...)
+}
+
+// Group returns a router registering its routes on the same route table, with
+// the given prefix prepended to their patterns and the given middleware
+// applied after the middleware of router.
+func (router *Router) Group(prefix string, middleware ...Middleware) *Router {
+	group := *router
+	group.prefix = router.prefix + strings.TrimSuffix(prefix, "/")
+	group.middleware = append(append([]Middleware{}, router.middleware...), middleware...)
+	return &group
+}
+
+// Handle registers the handler for the given method and pattern. Will panic if
+// the pattern is invalid or already registered for the method.
+func (router *Router) Handle(method string, pattern string, h Handler) *Route {
+	pattern = router.prefix + pattern
+
+	segments, err := parsePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, route := range router.table.routes {
+		if route.Method == method && samePattern(route.segments, segments) {
+			panic(fmt.Errorf("route %s %s conflicts with %s %s", method, pattern, route.Method, route.Pattern))
+		}
+	}
+
+	route := &Route{
+		Method:   method,
+		Pattern:  pattern,
+		handler:  chain(h, router.middleware),
+		segments: segments,
+	}
+	router.table.routes = append(router.table.routes, route)
+	return route
+}
+
+// Get registers the handler for GET requests matching the pattern.
+func (router *Router) Get(pattern string, h Handler) *Route {
+	return router.Handle(http.MethodGet, pattern, h)
+}
+
+// Post registers the handler for POST requests matching the pattern.
+func (router *Router) Post(pattern string, h Handler) *Route {
+	return router.Handle(http.MethodPost, pattern, h)
+}
+
+// Put registers the handler for PUT requests matching the pattern.
+func (router *Router) Put(pattern string, h Handler) *Route {
+	return router.Handle(http.MethodPut, pattern, h)
+}
+
+// Patch registers the handler for PATCH requests matching the pattern.
+func (router *Router) Patch(pattern string, h Handler) *Route {
+	return router.Handle(http.MethodPatch, pattern, h)
+}
+
+// Delete registers the handler for DELETE requests matching the pattern.
+func (router *Router) Delete(pattern string, h Handler) *Route {
+	return router.Handle(http.MethodDelete, pattern, h)
+}
+
+// NotFound sets the handler used for requests matching no route.
+func (router *Router) NotFound(h Handler) {
+	router.table.notFound = h
+}
+
+// MethodNotAllowed sets the handler used for requests matching a route for
+// another method. The Allow header is set on the response writer before the
+// handler is called.
+func (router *Router) MethodNotAllowed(h Handler) {
+	router.table.methodNotAllowed = h
+}
+
+// Routes returns the routes registered on the router and its groups.
+func (router *Router) Routes() []*Route {
+	return append([]*Route{}, router.table.routes...)
+}
+
+// ServeHTTP routes the request to the handler of the best matching route.
+func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, params, allowed := router.match(r.Method, r.URL.Path)
+	if route == nil && r.Method == http.MethodHead {
+		route, params, _ = router.match(http.MethodGet, r.URL.Path)
+	}
+
+	if route == nil {
+		if len(allowed) == 0 {
+			chain(router.notFound(), router.middleware).ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		if r.Method == http.MethodOptions {
+			chain(router.options(allowed), router.middleware).ServeHTTP(w, r)
+			return
+		}
+		chain(router.methodNotAllowed(allowed), router.middleware).ServeHTTP(w, r)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), routeKey{}, routeContext{
+		pattern: route.Pattern,
+		params:  params,
+	})
+	route.handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// match returns the best matching route for the method and path along with the
+// captured parameters. If no route matches the method, the methods allowed for
+// the path are returned instead, including HEAD for paths allowing GET and
+// OPTIONS.
+func (router *Router) match(method string, path string) (*Route, map[string]string, []string) {
+	segments := splitPath(path)
+
+	var best *Route
+	var bestParams map[string]string
+	var bestScore []int
+	var allowed []string
+
+	for _, route := range router.table.routes {
+		params, score, ok := matchSegments(route.segments, segments)
+		if !ok {
+			continue
+		}
+		if route.Method != method {
+			allowed = appendMethod(allowed, route.Method)
+			continue
+		}
+		if best == nil || compareScores(score, bestScore) > 0 {
+			best, bestParams, bestScore = route, params, score
+		}
+	}
+
+	if best != nil {
+		return best, bestParams, nil
+	}
+
+	if len(allowed) > 0 {
+		for _, m := range allowed {
+			if m == http.MethodGet {
+				allowed = appendMethod(allowed, http.MethodHead)
+				break
+			}
+		}
+		allowed = appendMethod(allowed, http.MethodOptions)
+	}
+	sort.Strings(allowed)
+	return nil, nil, allowed
+}
+
+// notFound returns the not found handler.
+func (router *Router) notFound() Handler {
+	if router.table.notFound != nil {
+		return router.table.notFound
+	}
+	return func(r *http.Request) Response {
+		return router.newBuilder().NotFound()
+	}
+}
+
+// methodNotAllowed returns the method not allowed handler.
+func (router *Router) methodNotAllowed(allowed []string) Handler {
+	if router.table.methodNotAllowed != nil {
+		return router.table.methodNotAllowed
+	}
+	return func(r *http.Request) Response {
+		return router.newBuilder().
+			WithStatus(http.StatusMethodNotAllowed).
+			WithHeaderEntry("Allow", strings.Join(allowed, ", "))
+	}
+}
+
+// options returns the handler answering OPTIONS requests.
+func (router *Router) options(allowed []string) Handler {
+	return func(r *http.Request) Response {
+		return router.newBuilder().
+			NoContent().
+			WithHeaderEntry("Allow", strings.Join(allowed, ", "))
+	}
+}
+
+// newBuilder returns a response builder using the router's response config.
+func (router *Router) newBuilder() response.Builder {
+	if router.config == nil {
+		return response.NewBuilder()
+	}
+	return response.NewBuilder(response.WithConfig(*router.config))
+}
+
+type routeKey struct{}
+
+type routeContext struct {
+	pattern string
+	params  map[string]string
+}
+
+// Param returns the value of the named path parameter captured by the Router,
+// or an empty string if there is no such parameter.
+func Param(r *http.Request, name string) string {
+	rc, _ := r.Context().Value(routeKey{}).(routeContext)
+	return rc.params[name]
+}
+
+// WithParams returns a shallow copy of r with the given path parameters, as if
+// captured by the Router. It allows handlers using Param to be called without a
+// router, e.g. in tests.
+func WithParams(r *http.Request, params map[string]string) *http.Request {
+	rc, _ := r.Context().Value(routeKey{}).(routeContext)
+	merged := make(map[string]string, len(rc.params)+len(params))
+	for name, value := range rc.params {
+		merged[name] = value
+	}
+	for name, value := range params {
+		merged[name] = value
+	}
+	rc.params = merged
+	return r.WithContext(context.WithValue(r.Context(), routeKey{}, rc))
+}
+
+// RoutePattern returns the pattern of the route matched by the Router, or an
+// empty string if the request has not been routed.
+func RoutePattern(r *http.Request) string {
+	rc, _ := r.Context().Value(routeKey{}).(routeContext)
+	return rc.pattern
+}
+
+// chain wraps h with the middleware, the first middleware being the outermost.
+func chain(h Handler, middleware []Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
+
+// parsePattern splits a pattern into its segments and validates them.
+func parsePattern(pattern string) ([]string, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return nil, fmt.Errorf("pattern %q must begin with a slash", pattern)
+	}
+
+	segments := splitPath(pattern)
+	names := map[string]bool{}
+	for i, segment := range segments {
+		switch {
+		case segment == "*":
+			if i != len(segments)-1 {
+				return nil, fmt.Errorf("pattern %q has a wildcard before the last segment", pattern)
+			}
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			name := segment[1 : len(segment)-1]
+			if name == "" || names[name] {
+				return nil, fmt.Errorf("pattern %q has an empty or duplicate parameter", pattern)
+			}
+			names[name] = true
+		case strings.ContainsAny(segment, "{}*"):
+			return nil, fmt.Errorf("pattern %q has an invalid segment %q", pattern, segment)
+		}
+	}
+	return segments, nil
+}
+
+// splitPath splits a path into its segments, keeping a trailing empty segment
+// for paths ending in a slash.
+func splitPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
+
+// matchSegments matches path segments against pattern segments. The returned
+// score ranks the match by specificity of each segment.
+func matchSegments(pattern []string, path []string) (map[string]string, []int, bool) {
+	var params map[string]string
+	score := make([]int, 0, len(pattern))
+
+	for i, segment := range pattern {
+		if segment == "*" {
+			if params == nil {
+				params = map[string]string{}
+			}
+			params["*"] = strings.Join(path[i:], "/")
+			return params, append(score, 0), true
+		}
+
+		if i >= len(path) {
+			return nil, nil, false
+		}
+
+		if strings.HasPrefix(segment, "{") {
+			if path[i] == "" {
+				return nil, nil, false
+			}
+			if params == nil {
+				params = map[string]string{}
+			}
+			params[segment[1:len(segment)-1]] = path[i]
+			score = append(score, 1)
+			continue
+		}
+
+		if segment != path[i] {
+			return nil, nil, false
+		}
+		score = append(score, 2)
+	}
+
+	if len(pattern) != len(path) {
+		return nil, nil, false
+	}
+	return params, score, true
+}
+
+// compareScores compares two match scores segment by segment.
+func compareScores(a []int, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return len(a) - len(b)
+}
+
+// samePattern reports whether two patterns match the same paths.
+func samePattern(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		aParam := strings.HasPrefix(a[i], "{")
+		bParam := strings.HasPrefix(b[i], "{")
+		if aParam != bParam || (!aParam && a[i] != b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendMethod appends the method to methods unless already present.
+func appendMethod(methods []string, method string) []string {
+	for _, m := range methods {
+		if m == method {
+			return methods
+		}
+	}
+	return append(methods, method)
+}
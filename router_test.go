@@ -0,0 +1,243 @@
+package recoil
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func contentHandler(content string) Handler {
+	return func(r *http.Request) Response {
+		return response.NewBuilder(response.WithConfig(response.Config{
+			Formatter: response.PlainTextFormatter{},
+		})).WithContent(content)
+	}
+}
+
+func serve(h http.Handler, method string, target string) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(method, target, nil))
+	return rw
+}
+
+func TestRouter(t *testing.T) {
+
+	router := NewRouter()
+	router.Get("/", contentHandler("root"))
+	router.Get("/users", contentHandler("users"))
+	router.Get("/users/", contentHandler("users/"))
+	router.Get("/users/me", contentHandler("me"))
+	router.Get("/users/{id}", func(r *http.Request) Response {
+		return response.Content(Param(r, "id") + " " + RoutePattern(r))
+	})
+	router.Post("/users/{id}", contentHandler("post"))
+	router.Get("/users/{id}/posts/{post}", func(r *http.Request) Response {
+		return response.Content(Param(r, "id") + "/" + Param(r, "post"))
+	})
+	router.Get("/static/*", func(r *http.Request) Response {
+		return response.Content(Param(r, "*"))
+	})
+
+	tests := []struct {
+		method string
+		target string
+		body   string
+	}{
+		{http.MethodGet, "/", "root"},
+		{http.MethodGet, "/users", "users"},
+		{http.MethodGet, "/users/", "users/"},
+		{http.MethodGet, "/users/me", "me"},
+		{http.MethodGet, "/users/42", `"42 /users/{id}"`},
+		{http.MethodPost, "/users/42", "post"},
+		{http.MethodGet, "/users/42/posts/7", `"42/7"`},
+		{http.MethodGet, "/static/css/site.css", `"css/site.css"`},
+		{http.MethodGet, "/static/", `""`},
+	}
+
+	for _, test := range tests {
+		rw := serve(router, test.method, test.target)
+
+		assert.Equal(t, http.StatusOK, rw.Code, test.target)
+		assert.Equal(t, test.body, rw.Body.String(), test.target)
+	}
+}
+
+func TestRouterNotFound(t *testing.T) {
+
+	router := NewRouter()
+	router.Get("/users/{id}", contentHandler("user"))
+
+	for _, target := range []string{"/users", "/users/", "/users/1/posts", "/other"} {
+		rw := serve(router, http.MethodGet, target)
+
+		assert.Equal(t, http.StatusNotFound, rw.Code, target)
+		assert.Equal(t, "application/json", rw.Header().Get("Content-Type"), target)
+		assert.Equal(t, `{"message":"Not Found"}`, rw.Body.String(), target)
+	}
+}
+
+func TestRouterMethodNotAllowed(t *testing.T) {
+
+	router := NewRouter(WithResponseConfig(response.Config{
+		Formatter: response.XMLFormatter{},
+	}))
+	router.Get("/users/{id}", contentHandler("get"))
+	router.Delete("/users/{id}", contentHandler("delete"))
+	router.Put("/users/{id}", contentHandler("put"))
+
+	rw := serve(router, http.MethodPost, "/users/1")
+
+	body, _ := io.ReadAll(rw.Body)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rw.Code)
+	assert.Equal(t, "DELETE, GET, HEAD, OPTIONS, PUT", rw.Header().Get("Allow"))
+	assert.Equal(t, "application/xml", rw.Header().Get("Content-Type"))
+	assert.Contains(t, string(body), "<message>Method Not Allowed</message>")
+}
+
+func TestRouterCustomNotFound(t *testing.T) {
+
+	router := NewRouter()
+	router.NotFound(contentHandler("custom not found"))
+	router.MethodNotAllowed(contentHandler("custom method not allowed"))
+	router.Get("/", contentHandler("root"))
+
+	assert.Equal(t, "custom not found", serve(router, http.MethodGet, "/missing").Body.String())
+
+	rw := serve(router, http.MethodPost, "/")
+	assert.Equal(t, "custom method not allowed", rw.Body.String())
+	assert.Equal(t, "GET, HEAD, OPTIONS", rw.Header().Get("Allow"))
+}
+
+func TestRouterGroupsAndMiddleware(t *testing.T) {
+
+	tag := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(r *http.Request) Response {
+				res := next(r).(response.Builder)
+				return res.WithHeaderEntry("X-Middleware", append(res.Header().Values("X-Middleware"), name)...)
+			}
+		}
+	}
+
+	router := NewRouter()
+	router.Use(tag("root"))
+
+	api := router.Group("/api", tag("api"))
+	api.Get("/users", contentHandler("users"))
+
+	admin := api.Group("/admin/")
+	admin.Use(tag("admin"))
+	admin.Patch("/settings", contentHandler("settings"))
+
+	router.Get("/health", contentHandler("ok"))
+
+	rw := serve(router, http.MethodGet, "/api/users")
+	assert.Equal(t, "users", rw.Body.String())
+	assert.Equal(t, []string{"api", "root"}, rw.Header().Values("X-Middleware"))
+
+	rw = serve(router, http.MethodPatch, "/api/admin/settings")
+	assert.Equal(t, "settings", rw.Body.String())
+	assert.Equal(t, []string{"admin", "api", "root"}, rw.Header().Values("X-Middleware"))
+
+	rw = serve(router, http.MethodGet, "/health")
+	assert.Equal(t, []string{"root"}, rw.Header().Values("X-Middleware"))
+
+	rw = serve(router, http.MethodGet, "/missing")
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+	assert.Equal(t, []string{"root"}, rw.Header().Values("X-Middleware"))
+
+	assert.Len(t, router.Routes(), 3)
+	assert.Equal(t, "/api/admin/settings", router.Routes()[1].Pattern)
+}
+
+func TestRouterInvalidPatterns(t *testing.T) {
+
+	router := NewRouter()
+	router.Get("/users/{id}", contentHandler("user"))
+
+	for _, pattern := range []string{
+		"users",
+		"/users/{}",
+		"/users/{id}/{id}",
+		"/static/*/more",
+		"/users/x{id}",
+		"/users/{other}",
+	} {
+		assert.Panics(t, func() {
+			router.Get(pattern, contentHandler(""))
+		}, pattern)
+	}
+
+	assert.NotPanics(t, func() {
+		router.Post("/users/{other}", contentHandler(""))
+	})
+}
+
+func TestRouteMetadata(t *testing.T) {
+
+	type key struct{}
+
+	router := NewRouter()
+	route := router.Get("/", contentHandler("root")).WithMetadata(key{}, "value")
+
+	assert.Equal(t, "value", route.Metadata(key{}))
+	assert.Equal(t, "value", router.Routes()[0].Metadata(key{}))
+	assert.Nil(t, route.Metadata("other"))
+}
+
+func TestWithParams(t *testing.T) {
+
+	r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	r = WithParams(r, map[string]string{"id": "42"})
+	r = WithParams(r, map[string]string{"post": "7"})
+
+	assert.Equal(t, "42", Param(r, "id"))
+	assert.Equal(t, "7", Param(r, "post"))
+	assert.Equal(t, "", RoutePattern(r))
+}
+
+func TestRouterHead(t *testing.T) {
+
+	router := NewRouter()
+	router.Get("/", contentHandler("root"))
+	router.Get("/explicit", contentHandler("get"))
+	router.Handle(http.MethodHead, "/explicit", func(r *http.Request) Response {
+		return response.NoContent().WithHeaderEntry("X-Head", "explicit")
+	})
+	router.Post("/post", contentHandler("post"))
+
+	rw := serve(router, http.MethodHead, "/")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Empty(t, rw.Body.String())
+
+	rw = serve(router, http.MethodHead, "/explicit")
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+	assert.Equal(t, "explicit", rw.Header().Get("X-Head"))
+
+	rw = serve(router, http.MethodHead, "/post")
+	assert.Equal(t, http.StatusMethodNotAllowed, rw.Code)
+	assert.Equal(t, "OPTIONS, POST", rw.Header().Get("Allow"))
+}
+
+func TestRouterOptions(t *testing.T) {
+
+	router := NewRouter()
+	router.Get("/users/{id}", contentHandler("get"))
+	router.Delete("/users/{id}", contentHandler("delete"))
+	router.Handle(http.MethodOptions, "/custom", contentHandler("custom options"))
+
+	rw := serve(router, http.MethodOptions, "/users/1")
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+	assert.Equal(t, "DELETE, GET, HEAD, OPTIONS", rw.Header().Get("Allow"))
+	assert.Empty(t, rw.Header().Get("Content-Type"))
+	assert.Empty(t, rw.Body.String())
+
+	assert.Equal(t, "custom options", serve(router, http.MethodOptions, "/custom").Body.String())
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodOptions, "/missing").Code)
+}
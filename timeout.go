@@ -0,0 +1,156 @@
+package recoil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jhdrn/go-recoil/response"
+)
+
+// TimeoutOption is a functional option for configuring the Timeout
+// middleware.
+type TimeoutOption func(*timeout)
+
+// WithTimeoutConfig configures the Timeout middleware to use the given
+// response config for its 503 Service Unavailable response, like the router
+// does for its own responses using WithResponseConfig. By default
+// response.DefaultConfig is used.
+func WithTimeoutConfig(c response.Config) TimeoutOption {
+	return func(t *timeout) {
+		t.config = &c
+	}
+}
+
+// WithTimeoutHandler configures the Timeout middleware to respond using h
+// instead of with a 503 Service Unavailable response, e.g. to respond with
+// 504 Gateway Timeout.
+func WithTimeoutHandler(h Handler) TimeoutOption {
+	return func(t *timeout) {
+		t.onTimeout = h
+	}
+}
+
+// timeout is the configuration of the Timeout middleware.
+type timeout struct {
+	config    *response.Config
+	onTimeout Handler
+}
+
+// Timeout returns middleware limiting the time handlers have to return a
+// response. The handler is called with a request whose context is canceled
+// after d, and runs in its own goroutine. If it has not returned when the
+// context is done, a 503 Service Unavailable response is returned instead,
+// formatted using the configured response config, see WithTimeoutConfig and
+// WithTimeoutHandler.
+//
+// The context stays valid while the body of the handler's response is written,
+// and is canceled once the body has been closed. A panic in the handler is
+// propagated unless it occurs after the timeout.
+func Timeout(d time.Duration, options ...TimeoutOption) Middleware {
+	t := &timeout{}
+	for _, opt := range options {
+		opt(t)
+	}
+
+	onTimeout := t.onTimeout
+	if onTimeout == nil {
+		onTimeout = func(r *http.Request) Response {
+			if t.config == nil {
+				return response.NewBuilder().ServiceUnavailable(0)
+			}
+			return response.NewBuilder(response.WithConfig(*t.config)).ServiceUnavailable(0)
+		}
+	}
+
+	return func(next Handler) Handler {
+		return func(r *http.Request) Response {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			r = r.WithContext(ctx)
+
+			done := make(chan Response, 1)
+			panics := make(chan any, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panics <- p
+					}
+				}()
+				done <- bindRequest(next(r), r)
+			}()
+
+			select {
+			case res := <-done:
+				return timeoutResponse{Response: res, cancel: cancel}
+			case p := <-panics:
+				cancel()
+				panic(p)
+			case <-ctx.Done():
+				cancel()
+				return onTimeout(r)
+			}
+		}
+	}
+}
+
+// timeoutResponse is a response canceling the context of its handler once its
+// body has been closed.
+type timeoutResponse struct {
+	Response
+	cancel context.CancelFunc
+}
+
+// Body returns the body of the response, which cancels the context when
+// closed.
+func (res timeoutResponse) Body() io.Reader {
+	body := res.Response.Body()
+	if sized, ok := body.(interface{ Len() int }); ok {
+		return &sizedCancelingBody{cancelingBody{Reader: body, cancel: res.cancel}, sized}
+	}
+	return &cancelingBody{Reader: body, cancel: res.cancel}
+}
+
+// Close closes the response of the handler, if it is an io.Closer, and cancels
+// the context. It is used when the body is not written.
+func (res timeoutResponse) Close() error {
+	defer res.cancel()
+	if closer, ok := findResponse[io.Closer](res.Response); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// ContentLength returns the content length reported by the response, if any.
+func (res timeoutResponse) ContentLength() (int64, bool) {
+	return contentLength(res.Response)
+}
+
+// Unwrap returns the response of the handler.
+func (res timeoutResponse) Unwrap() Response {
+	return res.Response
+}
+
+// cancelingBody is a body canceling a context when closed.
+type cancelingBody struct {
+	io.Reader
+	cancel context.CancelFunc
+}
+
+func (b *cancelingBody) Close() error {
+	defer b.cancel()
+	if closer, ok := b.Reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// sizedCancelingBody is a cancelingBody reporting the length of its body.
+type sizedCancelingBody struct {
+	cancelingBody
+	sized interface{ Len() int }
+}
+
+func (b *sizedCancelingBody) Len() int {
+	return b.sized.Len()
+}
@@ -0,0 +1,140 @@
+package recoil
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jhdrn/go-recoil/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout(t *testing.T) {
+
+	var ctx context.Context
+	h := Timeout(time.Second)(func(r *http.Request) Response {
+		ctx = r.Context()
+		return response.OK().WithStream(bytes.NewReader([]byte("body")))
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "http://example.org", nil)
+	res := h(r)
+
+	assert.Equal(t, http.StatusOK, res.Status())
+	assert.NoError(t, ctx.Err())
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "body", rw.Body.String())
+	assert.Equal(t, "4", rw.Header().Get("Content-Length"))
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestTimeoutHead(t *testing.T) {
+
+	var ctx context.Context
+	stream := &closer{Reader: bytes.NewReader([]byte("body"))}
+	h := Timeout(time.Second)(func(r *http.Request) Response {
+		ctx = r.Context()
+		return response.OK().WithStream(stream)
+	})
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodHead, "http://example.org", nil))
+
+	assert.Empty(t, rw.Body.String())
+	assert.True(t, stream.closed)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestTimeoutOverrun(t *testing.T) {
+
+	errs := make(chan error, 1)
+	h := Timeout(10 * time.Millisecond)(func(r *http.Request) Response {
+		<-r.Context().Done()
+		errs <- r.Context().Err()
+		return response.OK()
+	})
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.org", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"message":"Service Unavailable"}`, rw.Body.String())
+	assert.ErrorIs(t, <-errs, context.DeadlineExceeded)
+}
+
+func TestTimeoutCustomResponse(t *testing.T) {
+
+	builder := response.NewBuilder(response.WithConfig(response.Config{
+		Formatter: response.XMLFormatter{},
+	}))
+
+	h := Timeout(10*time.Millisecond, WithTimeoutHandler(func(r *http.Request) Response {
+		return builder.GatewayTimeout()
+	}))(func(r *http.Request) Response {
+		<-r.Context().Done()
+		return response.OK()
+	})
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.org", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, rw.Code)
+	assert.Equal(t, "application/xml", rw.Header().Get("Content-Type"))
+}
+
+func TestTimeoutConfig(t *testing.T) {
+
+	config := response.Config{Formatter: response.XMLFormatter{}}
+
+	router := NewRouter(WithResponseConfig(config))
+	router.Use(Timeout(10*time.Millisecond, WithTimeoutConfig(config)))
+	router.Get("/", func(r *http.Request) Response {
+		<-r.Context().Done()
+		return response.OK()
+	})
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://example.org/", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	assert.Equal(t, "application/xml", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), "Service Unavailable")
+}
+
+func TestTimeoutPanic(t *testing.T) {
+
+	h := Timeout(time.Second)(func(r *http.Request) Response {
+		panic("handler panicked")
+	})
+
+	assert.PanicsWithValue(t, "handler panicked", func() {
+		h(httptest.NewRequest(http.MethodGet, "http://example.org", nil))
+	})
+}
+
+func TestTimeoutUnwrap(t *testing.T) {
+
+	h := Timeout(time.Second)(func(r *http.Request) Response {
+		return response.OK().
+			WithEarlyHints("</style.css>; rel=preload; as=style").
+			WithTrailer("X-Status", func() string { return "complete" })
+	})
+
+	res := h(httptest.NewRequest(http.MethodGet, "http://example.org", nil))
+
+	hinter, ok := findResponse[earlyHinter](res)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"</style.css>; rel=preload; as=style"}, hinter.EarlyHints())
+
+	trailers, ok := findResponse[trailerer](res)
+	assert.True(t, ok)
+	assert.Contains(t, trailers.Trailers(), "X-Status")
+}